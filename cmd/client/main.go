@@ -1,49 +1,107 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"os"
 	"os/signal"
+	"slices"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	pb "github.com/shagrat164/system-monitoring-daemon/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxEvents - сколько последних событий устройств показывать в режиме обновлений.
+const maxEvents = 10
+
 var (
 	addr     string // Адрес сервера
 	interval string // Интервал выдачи данных
 	duration string // Диапазон усреднения
+	snapshot bool   // Разовый снимок вместо потока
+	updates  bool   // Поток обновлений по подсистемам
+	watch    bool   // Двунаправленный поток с изменением настроек на лету
+	admin    bool   // Внутренние метрики демона через служебный интерфейс
+
+	historyOf string        // Запрос истории: подсистема[.метрика]
+	since     time.Duration // Глубина запроса истории
+	step      int           // Шаг усреднения истории
+
+	subsystems  string // Запрашиваемые подсистемы через запятую
+	disks       string // Фильтр устройств дисков
+	mountpoints string // Фильтр точек монтирования
+	interfaces  string // Фильтр сетевых интерфейсов
+	fdTopN      int    // Количество процессов в таблице дескрипторов
+	aggregates  string // Дополнительные агрегаты: подсистема=функции;...
+	ewma        bool   // EWMA вместо среднего за окно
+	halfLife    int    // Период полураспада EWMA
+	heartbeat   int    // Период сообщений о прогрессе накопления окна и heartbeat
+	align       bool   // Снимки на границах времени, кратных интервалу
 )
 
 func init() {
 	flag.StringVar(&addr, "addr", "localhost:50051", "the address to connect to")
-	flag.StringVar(&interval, "i", "5", "information release interval [s]")
-	flag.StringVar(&duration, "d", "15", "range of information averaging [s]")
+	flag.StringVar(&interval, "i", "5", "information release interval [s] or duration (e.g. 200ms)")
+	flag.StringVar(&duration, "d", "15", "range of information averaging [s] or duration (e.g. 2s)")
+	flag.BoolVar(&snapshot, "snapshot", false, "print one snapshot over the last d seconds and exit")
+	flag.BoolVar(&updates, "updates", false, "use per-subsystem update stream (shows which subsystems have no data)")
+	flag.BoolVar(&watch, "watch", false,
+		"use bidirectional stream; type \"i=1 d=60 s=cpu,load_avg\" + Enter to change settings without reconnecting")
+	flag.BoolVar(&admin, "admin", false, "print daemon internal metrics via the admin interface and exit")
+	flag.StringVar(&historyOf, "history", "", "print history of subsystem[.metric] (e.g. cpu.idle) and exit")
+	flag.DurationVar(&since, "since", 5*time.Minute, "history range back from now")
+	flag.IntVar(&step, "step", 0, "history averaging step [s] (0 = raw points)")
+	flag.StringVar(&subsystems, "s", "",
+		"comma-separated subsystems: load_avg,cpu,disk,filesystem,fd,net_proto,net_iface,raid,block_devices,daemon (default all)")
+	flag.StringVar(&disks, "disks", "", "comma-separated disk devices to show (default all)")
+	flag.StringVar(&mountpoints, "mounts", "", "comma-separated mountpoints to show (default all)")
+	flag.StringVar(&interfaces, "ifaces", "", "comma-separated network interfaces to show (default all)")
+	flag.IntVar(&fdTopN, "fd-top", 0, "number of processes in the file descriptors table (default server top_n)")
+	flag.BoolVar(&ewma, "ewma", false, "use exponentially weighted moving average instead of the window mean")
+	flag.IntVar(&halfLife, "half-life", 0, "EWMA half-life [s] (default d)")
+	flag.IntVar(&heartbeat, "heartbeat", 5, "warm-up progress and heartbeat period [s] (0 = off)")
+	flag.BoolVar(&align, "align", false,
+		"align snapshots to wall-clock multiples of i (identical for all clients with the same request)")
+	flag.StringVar(&aggregates, "agg", "",
+		"extra aggregates per subsystem, e.g. \"cpu=max,p95;disk=p99\" (mean,min,max,p50,p95,p99,stddev,last)")
 }
 
 func main() {
 	flag.Parse()
 
-	intv, err := strconv.Atoi(interval)
+	intv, err := parseSeconds(interval)
 	if err != nil {
 		log.Printf("Convert param i error: %v\n", err)
 		return
 	}
 
-	dur, err := strconv.Atoi(duration)
+	dur, err := parseSeconds(duration)
 	if err != nil {
 		log.Printf("Convert param d error: %v\n", err)
 		return
 	}
 
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// Пинги не чаще min_time сервера по умолчанию: обрыв соединения заметен и при молчащем потоке
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}))
 	if err != nil {
 		log.Printf("did not connect: %v\n", err)
 		return
@@ -55,12 +113,93 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	r, err := c.GetStats(ctx, &pb.StatsRequest{Interval: int32(intv), Duration: int32(dur)}) //nolint:gosec
+	selected := splitList(subsystems)
+	options := &pb.SubsystemOptions{
+		Disks:       splitList(disks),
+		Mountpoints: splitList(mountpoints),
+		Interfaces:  splitList(interfaces),
+		FdTopN:      int32(fdTopN), //nolint:gosec
+	}
+
+	aggregations, err := parseAggregations(aggregates)
+	if err != nil {
+		log.Printf("Convert param agg error: %v\n", err)
+		return
+	}
+
+	averaging := pb.AveragingMode_AVERAGING_WINDOW
+	if ewma {
+		averaging = pb.AveragingMode_AVERAGING_EWMA
+	}
+
+	if historyOf != "" {
+		printHistory(ctx, c)
+		return
+	}
+
+	if admin {
+		stats, err := pb.NewAdminClient(conn).GetDaemonStats(ctx, &pb.DaemonStatsRequest{})
+		if err != nil {
+			log.Printf("could not get daemon stats: %v\n", err)
+			return
+		}
+		printDaemonStats(stats)
+		return
+	}
+
+	if snapshot {
+		resp, err := c.GetSnapshot(ctx, &pb.SnapshotRequest{
+			Duration:     int32(dur / time.Second), //nolint:gosec
+			Subsystems:   selected,
+			Options:      options,
+			Aggregations: aggregations,
+			Averaging:    averaging,
+			HalfLife:     int32(halfLife), //nolint:gosec
+		})
+		if err != nil {
+			log.Printf("could not get snapshot: %v\n", err)
+			return
+		}
+		fmt.Printf("Address server: %s\n", addr)
+		printMeta(resp.GetMeta())
+		printCoverage(resp)
+		printStats(resp.GetStats(), selected)
+		return
+	}
+
+	req := &pb.StatsRequest{
+		Interval:     int32(intv / time.Second), //nolint:gosec
+		Duration:     int32(dur / time.Second),  //nolint:gosec
+		Subsystems:   selected,
+		Options:      options,
+		Aggregations: aggregations,
+		Averaging:    averaging,
+		HalfLife:     int32(halfLife),  //nolint:gosec
+		Heartbeat:    int32(heartbeat), //nolint:gosec
+		Align:        align,
+	}
+	// Доли секунды передаются только в миллисекундах (версия 2 запроса)
+	if intv%time.Second != 0 || dur%time.Second != 0 {
+		req.IntervalMs = int32(intv / time.Millisecond) //nolint:gosec
+		req.DurationMs = int32(dur / time.Millisecond)  //nolint:gosec
+	}
+
+	if updates {
+		runUpdates(ctx, c, req, selected)
+		return
+	}
+	if watch {
+		runWatch(ctx, c, req)
+		return
+	}
+
+	r, err := c.GetStats(ctx, req)
 	if err != nil {
 		log.Printf("could not great: %v\n", err)
 		return
 	}
 
+	var last *pb.StatsResponse // Последний ответ с данными, перерисовывается при heartbeat
 	for {
 		stats, err := r.Recv()
 		if errors.Is(err, io.EOF) {
@@ -75,14 +214,455 @@ func main() {
 		// Очистка экрана
 		clearTerminal()
 		fmt.Printf("Address server: %s\n", addr)
-		fmt.Printf("Internal = %s[s] Duration = %s[s]\n\n", interval, duration)
+		fmt.Printf("Internal = %s[s] Duration = %s[s]\n", interval, duration)
+
+		if status := stats.GetStatus(); status != nil {
+			if status.GetKind() == pb.StreamStatusKind_STREAM_WARMUP {
+				printWarmup(status)
+			} else if last != nil {
+				printMeta(last.GetMeta())
+				printStats(last, selected)
+			}
+			fmt.Printf("Server alive: %s\n", time.Now().Format("15:04:05"))
+			continue
+		}
+		last = stats
+
+		printMeta(stats.GetMeta())
 
 		// Вывод информации
-		printLoadAvgTable(stats)
-		printCPUTable(stats)
-		printDiskTable(stats)
-		printFiileSystemTable(stats)
+		printStats(stats, selected)
+	}
+}
+
+// Приём потока обновлений по подсистемам; экран перерисовывается при каждом обновлении.
+func runUpdates(ctx context.Context, c pb.MonitoringClient, req *pb.StatsRequest, selected []string) {
+	r, err := c.Subscribe(ctx, req)
+	if err != nil {
+		log.Printf("could not subscribe: %v\n", err)
+		return
+	}
+
+	latest := make(map[string]*pb.SubsystemUpdate)
+	warmup := make(map[string]*pb.WarmupProgress)
+	var events []*pb.EntityEvent
+	var alive time.Time // Время последнего сообщения сервера
+	for {
+		update, err := r.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Println("Recive EOF. Close client.")
+			break
+		}
+		if err != nil {
+			log.Printf("err from Recv(): %v\n", err)
+			break
+		}
+		alive = time.Now()
+		if status := update.GetStatus(); status != nil {
+			clear(warmup)
+			for _, w := range status.GetWarmup() {
+				warmup[w.GetSubsystem()] = w
+			}
+		} else {
+			latest[update.GetSubsystem()] = update
+		}
+		events = append(events, update.GetEvents()...)
+		if len(events) > maxEvents {
+			events = events[len(events)-maxEvents:]
+		}
+
+		// Собираем последние обновления в общий ответ для вывода таблиц
+		stats := &pb.StatsResponse{}
+		for _, u := range latest {
+			applyUpdate(stats, u)
+		}
+		stats.Events = events
+
+		clearTerminal()
+		fmt.Printf("Address server: %s\n", addr)
+		fmt.Printf("Internal = %s[s] Duration = %s[s]\n", interval, duration)
+		fmt.Printf("Server alive: %s\n", alive.Format("15:04:05"))
+		if update.GetStatus() == nil {
+			printMeta(update.GetMeta())
+		}
+
+		for _, t := range tables {
+			if len(selected) > 0 && !slices.Contains(selected, t.subsystem) {
+				continue
+			}
+			u, ok := latest[t.subsystem]
+			if w, warming := warmup[t.subsystem]; !ok && warming {
+				fmt.Printf("[%s] warming up %s\n\n", t.subsystem, progressBar(w))
+				continue
+			}
+			if !ok {
+				fmt.Printf("[%s] no data\n\n", t.subsystem)
+				continue
+			}
+			fmt.Printf("[%s] updated %s\n", t.subsystem, u.GetTime().AsTime().Local().Format("15:04:05"))
+			t.print(stats)
+		}
+		printAggregates(stats)
+		printEvents(stats)
+	}
+}
+
+// Двунаправленный поток: строки из stdin вида "i=1 d=60 s=cpu" меняют настройки на лету.
+func runWatch(ctx context.Context, c pb.MonitoringClient, req *pb.StatsRequest) {
+	stream, err := c.Watch(ctx)
+	if err != nil {
+		log.Printf("could not watch: %v\n", err)
+		return
+	}
+	if err := stream.Send(req); err != nil {
+		log.Printf("could not send settings: %v\n", err)
+		return
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			next := proto.Clone(req).(*pb.StatsRequest)
+			if err := parseSettings(scanner.Text(), next); err != nil {
+				log.Printf("bad settings: %v\n", err)
+				continue
+			}
+			if err := stream.Send(next); err != nil {
+				log.Printf("could not send settings: %v\n", err)
+				return
+			}
+			req = next
+		}
+		_ = stream.CloseSend()
+	}()
+
+	for {
+		stats, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Println("Recive EOF. Close client.")
+			break
+		}
+		if err != nil {
+			log.Printf("err from Recv(): %v\n", err)
+			break
+		}
+
+		// Выводим подсистемы, по которым сервер прислал покрытие
+		var selected []string
+		for _, c := range stats.GetMeta().GetSubsystems() {
+			selected = append(selected, c.GetSubsystem())
+		}
+
+		clearTerminal()
+		fmt.Printf("Address server: %s\n", addr)
+		printMeta(stats.GetMeta())
+		printStats(stats, selected)
+	}
+}
+
+// Разбор строки настроек "i=1 d=60 s=cpu,load_avg a=cpu=max,p95 m=ewma h=30 align=1" в запрос.
+func parseSettings(line string, req *pb.StatsRequest) error {
+	for _, field := range strings.Fields(line) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("expected key=value, got %q", field)
+		}
+		switch key {
+		case "i", "d", "h":
+			v, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("convert param %s error: %w", key, err)
+			}
+			switch key {
+			case "i":
+				req.Interval = int32(v) //nolint:gosec
+			case "d":
+				req.Duration = int32(v) //nolint:gosec
+			default:
+				req.HalfLife = int32(v) //nolint:gosec
+			}
+		case "m":
+			mode, ok := pb.AveragingMode_value["AVERAGING_"+strings.ToUpper(value)]
+			if !ok {
+				return fmt.Errorf("unknown averaging mode %q", value)
+			}
+			req.Averaging = pb.AveragingMode(mode)
+			if req.Averaging == pb.AveragingMode_AVERAGING_WINDOW {
+				req.HalfLife = 0
+			}
+		case "align":
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("convert param %s error: %w", key, err)
+			}
+			req.Align = v
+		case "s":
+			req.Subsystems = splitList(value)
+		case "a":
+			aggregations, err := parseAggregations(value)
+			if err != nil {
+				return err
+			}
+			req.Aggregations = aggregations
+		default:
+			return fmt.Errorf("unknown setting %q", key)
+		}
+	}
+	return nil
+}
+
+// Перенос данных обновления подсистемы в общий ответ.
+func applyUpdate(stats *pb.StatsResponse, u *pb.SubsystemUpdate) {
+	stats.Aggregates = append(stats.Aggregates, u.GetAggregates()...)
+
+	switch p := u.GetPayload().(type) {
+	case *pb.SubsystemUpdate_LoadAverage:
+		stats.LoadAverage_1Min = p.LoadAverage.GetLoad_1Min()
+		stats.LoadAverage_5Min = p.LoadAverage.GetLoad_5Min()
+		stats.LoadAverage_15Min = p.LoadAverage.GetLoad_15Min()
+	case *pb.SubsystemUpdate_Cpu:
+		stats.CpuUser = p.Cpu.GetUser()
+		stats.CpuSystem = p.Cpu.GetSystem()
+		stats.CpuIdle = p.Cpu.GetIdle()
+	case *pb.SubsystemUpdate_Disk:
+		stats.DiskStats = p.Disk.GetDisks()
+	case *pb.SubsystemUpdate_Filesystem:
+		stats.FilesystemStats = p.Filesystem.GetFilesystems()
+	case *pb.SubsystemUpdate_Fd:
+		stats.FdStats = p.Fd
+	case *pb.SubsystemUpdate_NetProto:
+		stats.NetProtoStats = p.NetProto
+	case *pb.SubsystemUpdate_NetIface:
+		stats.NetIfaceStats = p.NetIface.GetInterfaces()
+	case *pb.SubsystemUpdate_Raid:
+		stats.RaidArrays = p.Raid.GetArrays()
+	case *pb.SubsystemUpdate_BlockDevices:
+		stats.BlockDevices = p.BlockDevices.GetDevices()
+	case *pb.SubsystemUpdate_Daemon:
+		stats.DaemonStats = p.Daemon
+	}
+}
+
+// Таблицы подсистем в порядке вывода.
+var tables = []struct {
+	subsystem string
+	print     func(*pb.StatsResponse)
+}{
+	{"load_avg", printLoadAvgTable},
+	{"cpu", printCPUTable},
+	{"disk", printDiskTable},
+	{"filesystem", printFiileSystemTable},
+	{"fd", printFDTable},
+	{"net_proto", printNetProtoTable},
+	{"net_iface", printNetIfaceTable},
+	{"raid", printRAIDTable},
+	{"block_devices", printBlockDevicesTable},
+	{"daemon", printDaemonTable},
+}
+
+// Вывод таблиц статистики запрошенных подсистем (все, если список пуст).
+func printStats(stats *pb.StatsResponse, selected []string) {
+	for _, t := range tables {
+		if len(selected) == 0 || slices.Contains(selected, t.subsystem) {
+			t.print(stats)
+		}
 	}
+	printAggregates(stats)
+	printEvents(stats)
+}
+
+// Разбор списка через запятую.
+func splitList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Разбор агрегатов вида "cpu=max,p95;disk=p99".
+func parseAggregations(s string) ([]*pb.AggregationRequest, error) {
+	var result []*pb.AggregationRequest
+	for _, item := range strings.Split(s, ";") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		subsystem, functions, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected subsystem=functions, got %q", item)
+		}
+		req := &pb.AggregationRequest{Subsystem: strings.TrimSpace(subsystem)}
+		for _, name := range splitList(functions) {
+			fn, ok := pb.Aggregation_value["AGGREGATION_"+strings.ToUpper(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown aggregation %q", name)
+			}
+			req.Functions = append(req.Functions, pb.Aggregation(fn))
+		}
+		result = append(result, req)
+	}
+	return result, nil
+}
+
+// Вывод истории метрики за последние since.
+func printHistory(ctx context.Context, c pb.MonitoringClient) {
+	subsystem, metric, _ := strings.Cut(historyOf, ".")
+	now := time.Now()
+	resp, err := c.QueryHistory(ctx, &pb.HistoryRequest{
+		Subsystem: subsystem,
+		Metric:    metric,
+		From:      timestamppb.New(now.Add(-since)),
+		To:        timestamppb.New(now),
+		Step:      int32(step), //nolint:gosec
+	})
+	if err != nil {
+		log.Printf("could not query history: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Resolution = %d[s]\n\n", resp.GetResolution())
+	for _, series := range resp.GetSeries() {
+		fmt.Printf("%s.%s %s:\n", series.GetSubsystem(), series.GetMetric(), series.GetLabel())
+		fmt.Printf("  %-20s %-10s %-10s %-10s %s\n", "Time", "Avg", "Min", "Max", "Count")
+		for _, p := range series.GetPoints() {
+			fmt.Printf("  %-20s %-10.2f %-10.2f %-10.2f %d\n", p.GetTime().AsTime().Local().Format("2006-01-02 15:04:05"),
+				p.GetValue(), p.GetMin(), p.GetMax(), p.GetCount())
+		}
+		fmt.Println()
+	}
+}
+
+// Метаданные снимка: хост, номер сообщения и окно усреднения.
+func printMeta(meta *pb.SnapshotMeta) {
+	fmt.Printf("Host = %s Boot ID = %s Seq = %d Window = %s - %s",
+		meta.GetHostname(), meta.GetBootId(), meta.GetSequence(),
+		meta.GetWindowStart().AsTime().Local().Format("15:04:05"),
+		meta.GetWindowEnd().AsTime().Local().Format("15:04:05"))
+	if meta.GetHalfLife() > 0 {
+		fmt.Printf(" EWMA half-life = %d[s]", meta.GetHalfLife())
+	}
+	if meta.GetDropped() > 0 {
+		fmt.Printf(" Dropped = %d", meta.GetDropped())
+	}
+	fmt.Println()
+	printGaps(meta.GetSubsystems())
+	fmt.Println()
+}
+
+// Подсистемы с пропущенными замерами: доля полученных, признак устаревания и последняя ошибка.
+func printGaps(coverage []*pb.SubsystemCoverage) {
+	for _, c := range coverage {
+		if c.GetExpected() == 0 || c.GetSamples() >= c.GetExpected() {
+			continue
+		}
+		state := "GAPS"
+		if c.GetStale() {
+			state = "STALE"
+		}
+		fmt.Printf("  [%s] %s %d/%d samples", c.GetSubsystem(), state, c.GetSamples(), c.GetExpected())
+		if c.GetLastError() != "" {
+			fmt.Printf(", last error at %s: %s",
+				c.GetLastErrorTime().AsTime().Local().Format("15:04:05"), c.GetLastError())
+		}
+		fmt.Println()
+	}
+	printDegraded(coverage)
+}
+
+// Подсистемы, коллектор которых недавно перезапускался после паники.
+func printDegraded(coverage []*pb.SubsystemCoverage) {
+	for _, c := range coverage {
+		if !c.GetDegraded() {
+			continue
+		}
+		fmt.Printf("  [%s] DEGRADED %d restarts, last panic at %s: %s\n", c.GetSubsystem(), c.GetRestarts(),
+			c.GetLastPanicTime().AsTime().Local().Format("15:04:05"), c.GetLastPanic())
+	}
+}
+
+// Значение параметра в секундах (целое число) или в формате time.ParseDuration.
+func parseSeconds(value string) (time.Duration, error) {
+	if n, err := strconv.Atoi(value); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// Покрытие периода снимка замерами.
+func printCoverage(resp *pb.SnapshotResponse) {
+	fmt.Printf("Duration = %d[s] Covered = %.1f[s]\n", resp.GetDuration(), resp.GetCoveredSeconds())
+	for _, c := range resp.GetCoverage() {
+		fmt.Printf("  %-14s %4d/%-4d samples %7.1f[s]", c.GetSubsystem(), c.GetSamples(), c.GetExpected(),
+			c.GetCoveredSeconds())
+		if c.GetStale() {
+			fmt.Print(" STALE")
+		}
+		if c.GetRestarts() > 0 {
+			fmt.Printf(" restarts=%d", c.GetRestarts())
+		}
+		fmt.Println()
+	}
+	printDegraded(resp.GetCoverage())
+	fmt.Println()
+}
+
+// Таблица запрошенных агрегатов.
+func printAggregates(stats *pb.StatsResponse) {
+	if len(stats.GetAggregates()) == 0 {
+		return
+	}
+	fmt.Println("Aggregates:")
+	fmt.Printf("  %-14s %-28s %-12s %-8s %-12s %s\n", "Subsystem", "Metric", "Label", "Func", "Value", "Samples")
+	for _, a := range stats.GetAggregates() {
+		fn := strings.ToLower(strings.TrimPrefix(a.GetFunction().String(), "AGGREGATION_"))
+		fmt.Printf("  %-14s %-28s %-12s %-8s %-12.2f %d\n",
+			a.GetSubsystem(), a.GetMetric(), a.GetLabel(), fn, a.GetValue(), a.GetSamples())
+	}
+	fmt.Println()
+}
+
+// Прогресс накопления окна подсистем до первого ответа.
+func printWarmup(status *pb.StreamStatus) {
+	fmt.Println("Warming up:")
+	for _, w := range status.GetWarmup() {
+		fmt.Printf("  %-14s %s\n", w.GetSubsystem(), progressBar(w))
+	}
+	fmt.Println()
+}
+
+// progressBar - индикатор накопленных секунд окна подсистемы.
+func progressBar(w *pb.WarmupProgress) string {
+	const width = 20
+	filled := width
+	if w.GetRequiredSeconds() > 0 {
+		filled = min(int(w.GetAccumulatedSeconds()/w.GetRequiredSeconds()*width), width)
+	}
+	return fmt.Sprintf("[%s%s] %.0f/%.0f[s]", strings.Repeat("#", filled), strings.Repeat(".", width-filled),
+		w.GetAccumulatedSeconds(), w.GetRequiredSeconds())
+}
+
+// Список событий появления и удаления устройств и точек монтирования.
+func printEvents(stats *pb.StatsResponse) {
+	if len(stats.GetEvents()) == 0 {
+		return
+	}
+	fmt.Println("Events:")
+	for _, e := range stats.GetEvents() {
+		kind := strings.ToLower(strings.TrimPrefix(e.GetKind().String(), "ENTITY_"))
+		fmt.Printf("  %s %-14s %-8s %s\n",
+			e.GetTime().AsTime().Local().Format("15:04:05"), e.GetSubsystem(), kind, e.GetName())
+	}
+	fmt.Println()
+}
+
+// partialMark - отметка сущности, данные которой покрывают только часть окна.
+func partialMark(partial bool) string {
+	if partial {
+		return "*"
+	}
+	return ""
 }
 
 // Очистка экрана.
@@ -111,10 +691,10 @@ func printCPUTable(stats *pb.StatsResponse) {
 // Таблица статистики дисков.
 func printDiskTable(stats *pb.StatsResponse) {
 	fmt.Println("Disk Usage:")
-	fmt.Printf("  %-10s %-8s %-8s\n", "Device", "TPS", "KB/s")
+	fmt.Printf("  %-10s %-8s %-10s %-10s %-10s %s\n", "Device", "TPS", "Read KB/s", "Write KB/s", "KB/s", "Mountpoints")
 	for _, disk := range stats.DiskStats {
-		fmt.Printf("  %-10s %-8.2f %-8.2f\n",
-			disk.GetDevice(), disk.GetTps(), disk.GetKbTotal())
+		fmt.Printf("  %-10s %-8.2f %-10.2f %-10.2f %-10.2f %s\n", disk.GetDevice()+partialMark(disk.GetPartial()), disk.GetTps(),
+			disk.GetKbRead(), disk.GetKbWrite(), disk.GetKbTotal(), strings.Join(disk.GetMountpoints(), ","))
 	}
 	fmt.Println()
 }
@@ -122,13 +702,142 @@ func printDiskTable(stats *pb.StatsResponse) {
 // Таблица статистики файолвых систем.
 func printFiileSystemTable(stats *pb.StatsResponse) {
 	fmt.Println("Filesystem Usage:")
-	fmt.Printf("  %-15s %-15s %-12s %-8s %-12s %-8s\n",
-		"Filesystem", "Mount Point", "Used MB", "Used %", "Inodes Used", "Inodes %")
+	fmt.Printf("  %-15s %-15s %-12s %-8s %-12s %-8s %s\n",
+		"Filesystem", "Mount Point", "Used MB", "Used %", "Inodes Used", "Inodes %", "Devices")
 	for _, fs := range stats.FilesystemStats {
-		fmt.Printf("  %-15s %-15s %-12.2f %-8.2f %-12.0f %-8.2f\n",
-			fs.GetFilesystem(), fs.GetMountpoint(),
+		fmt.Printf("  %-15s %-15s %-12.2f %-8.2f %-12.0f %-8.2f %s\n",
+			fs.GetFilesystem(), fs.GetMountpoint()+partialMark(fs.GetPartial()),
 			fs.GetUsedMb(), fs.GetUsedPercent(),
-			fs.GetInodesUsed(), fs.GetInodesPercent())
+			fs.GetInodesUsed(), fs.GetInodesPercent(), strings.Join(fs.GetBackingDevices(), ","))
+	}
+	fmt.Println()
+}
+
+// Таблица статистики файловых дескрипторов.
+func printFDTable(stats *pb.StatsResponse) {
+	fd := stats.GetFdStats()
+	fmt.Println("File Descriptors:")
+	fmt.Printf("  %-12s %-12s %-8s\n", "Allocated", "Max", "Used %")
+	fmt.Printf("  %-12.0f %-12.0f %-8.2f\n", fd.GetAllocated(), fd.GetMax(), fd.GetUsedPercent())
+	fmt.Printf("  %-2s %-8s %-16s %-10s %-10s %-8s\n", "", "PID", "Command", "Open", "Limit", "Used %")
+	for _, p := range fd.GetProcesses() {
+		mark := ""
+		if p.GetOverThreshold() {
+			mark = "!"
+		}
+		fmt.Printf("  %-2s %-8d %-16s %-10.0f %-10.0f %-8.2f\n",
+			mark, p.GetPid(), p.GetCommand(), p.GetOpenFds(), p.GetLimit(), p.GetUsedPercent())
+	}
+	fmt.Println()
+}
+
+// Таблица счётчиков сетевых протоколов.
+func printNetProtoTable(stats *pb.StatsResponse) {
+	fmt.Println("Network Protocols:")
+	fmt.Printf("  %-8s %-20s %-10s %-12s\n", "Proto", "Counter", "Rate/s", "Total")
+	for _, c := range stats.GetNetProtoStats().GetCounters() {
+		fmt.Printf("  %-8s %-20s %-10.2f %-12.0f\n",
+			c.GetProtocol(), c.GetName(), c.GetRate(), c.GetTotal())
+	}
+	fmt.Println()
+}
+
+// Таблица статистики сетевых интерфейсов.
+func printNetIfaceTable(stats *pb.StatsResponse) {
+	fmt.Println("Network Interfaces:")
+	fmt.Printf("  %-10s %-12s %-12s %-8s %-8s %-6s %-8s %-10s %-8s\n",
+		"Interface", "RX B/s", "TX B/s", "Util %", "Mb/s", "Duplex", "MTU", "State", "Flaps")
+	for _, i := range stats.GetNetIfaceStats() {
+		fmt.Printf("  %-10s %-12.2f %-12.2f %-8.2f %-8.0f %-6s %-8.0f %-10s %-8.0f\n",
+			i.GetName()+partialMark(i.GetPartial()), i.GetRxBytesPerSec(), i.GetTxBytesPerSec(), i.GetUtilizationPercent(),
+			i.GetSpeedMbps(), i.GetDuplex(), i.GetMtu(), i.GetOperstate(), i.GetCarrierChangesPeriod())
+	}
+	fmt.Println()
+}
+
+// Таблица состояния RAID-массивов.
+func printRAIDTable(stats *pb.StatsResponse) {
+	fmt.Println("RAID Arrays:")
+	fmt.Printf("  %-8s %-8s %-10s %-10s %-8s %-10s %-10s %-10s %s\n",
+		"Array", "Level", "Health", "State", "Disks", "Action", "Progress %", "KB/s", "Members")
+	for _, a := range stats.GetRaidArrays() {
+		members := make([]string, 0, len(a.GetMembers()))
+		for _, m := range a.GetMembers() {
+			members = append(members, fmt.Sprintf("%s(%s)", m.GetDevice(), m.GetState()))
+		}
+		health := strings.TrimPrefix(a.GetHealth().String(), "RAID_HEALTH_")
+		fmt.Printf("  %-8s %-8s %-10s %-10s %-8s %-10s %-10.2f %-10.0f %s\n",
+			a.GetName(), a.GetLevel(), health, a.GetState(),
+			fmt.Sprintf("%d/%d", a.GetActiveDisks(), a.GetRaidDisks()),
+			a.GetSyncAction(), a.GetSyncProgress(), a.GetSyncSpeedKbs(), strings.Join(members, " "))
+	}
+	fmt.Println()
+}
+
+// Таблица инвентаря блочных устройств.
+func printBlockDevicesTable(stats *pb.StatsResponse) {
+	fmt.Println("Block Devices:")
+	fmt.Printf("  %-10s %-8s %-6s %-10s %-4s %-12s %-20s %s\n",
+		"Device", "Dev", "Type", "Size GB", "Rot", "Scheduler", "Model", "Mountpoints")
+	for _, d := range stats.GetBlockDevices() {
+		rot := "no"
+		if d.GetRotational() {
+			rot = "yes"
+		}
+		fmt.Printf("  %-10s %-8s %-6s %-10.2f %-4s %-12s %-20s %s\n",
+			d.GetName(), d.GetDev(), d.GetType(), d.GetSizeBytes()/(1<<30), rot,
+			d.GetScheduler(), d.GetModel(), strings.Join(d.GetMountpoints(), ","))
+	}
+	fmt.Println()
+}
+
+func printDaemonTable(stats *pb.StatsResponse) {
+	if stats.GetDaemonStats() == nil {
+		return
+	}
+	printDaemonStats(stats.GetDaemonStats())
+}
+
+// Вывод внутренних метрик демона: ресурсы процесса, потоки и гистограммы длительностей.
+func printDaemonStats(d *pb.DaemonStats) {
+	fmt.Println("Daemon:")
+	fmt.Printf("  CPU %% = %.2f, RSS MB = %.2f, Goroutines = %.0f, Queued = %.1f (max %d)\n",
+		d.GetCpuPercent(), d.GetRssMb(), d.GetGoroutines(), d.GetQueueDepth(), d.GetMaxQueueDepth())
+	streams := make([]string, 0, len(d.GetStreams()))
+	for _, s := range d.GetStreams() {
+		streams = append(streams, fmt.Sprintf("%s=%d", s.GetRpc(), s.GetActive()))
+	}
+	fmt.Printf("  Streams: %s\n", strings.Join(streams, " "))
+
+	for _, group := range []struct {
+		title      string
+		histograms []*pb.LatencyHistogram
+	}{
+		{"Collectors", d.GetCollectors()},
+		{"Commands", d.GetCommands()},
+	} {
+		if len(group.histograms) == 0 {
+			continue
+		}
+		bounds := group.histograms[0].GetBoundsMs()
+		header := make([]string, 0, len(bounds)+1)
+		for _, b := range bounds {
+			header = append(header, fmt.Sprintf("<=%gms", b))
+		}
+		header = append(header, "more")
+		fmt.Printf("  %-14s %-8s %-8s %-10s %s\n", group.title, "Count", "Errors", "Avg ms", strings.Join(header, " "))
+		for _, h := range group.histograms {
+			var avg float64
+			if h.GetCount() > 0 {
+				avg = h.GetSumMs() / float64(h.GetCount())
+			}
+			counts := make([]string, 0, len(h.GetCounts()))
+			for _, c := range h.GetCounts() {
+				counts = append(counts, strconv.FormatUint(c, 10))
+			}
+			fmt.Printf("  %-14s %-8d %-8d %-10.2f %s\n", h.GetName(), h.GetCount(), h.GetErrors(), avg,
+				strings.Join(counts, " "))
+		}
 	}
 	fmt.Println()
 }
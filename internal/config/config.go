@@ -13,6 +13,21 @@ type Config struct {
 	GRPCPort string        `toml:"grpc_port"` // Порт gRPC-сервера
 	Logger   LoggerConfig  `toml:"logger"`    // Конфигурация логгера
 	Enabled  MetricsConfig `toml:"metrics"`   // Включенные подсистемы
+	FD       FDConfig      `toml:"fd"`        // Настройки сбора файловых дескрипторов
+	Engine   EngineConfig  `toml:"engine"`    // Настройки общего движка сбора
+	Request  RequestConfig `toml:"request"`   // Ограничения параметров запросов клиентов
+	History  HistoryConfig `toml:"history"`   // Хранение истории для QueryHistory
+	// Появление и исчезновение дисков, точек монтирования и интерфейсов
+	Lifecycle LifecycleConfig `toml:"lifecycle"`
+	Keepalive KeepaliveConfig `toml:"keepalive"` // Keepalive gRPC-соединений
+	Coverage  CoverageConfig  `toml:"coverage"`  // Достоверность значений при неудачных замерах
+	Commands  CommandsConfig  `toml:"commands"`  // Ограничения внешних команд (df)
+	// Перезапуск коллекторов после паники
+	Supervisor SupervisorConfig `toml:"supervisor"`
+	// Очереди отправки медленным клиентам
+	Backpressure BackpressureConfig `toml:"backpressure"`
+	// Базовое разрешение опроса каждой подсистемы
+	Resolution ResolutionConfig `toml:"resolution"`
 }
 
 // LoggerConfig структура конфигурации логгера.
@@ -27,6 +42,103 @@ type MetricsConfig struct {
 	CPU        bool `toml:"cpu"`        // Сбор информации о ЦПУ
 	Disk       bool `toml:"disk"`       // Сбор информации о дисках
 	Filesystem bool `toml:"filesystem"` // Сбор информации о файловых системах
+	FD         bool `toml:"fd"`         // Сбор информации о файловых дескрипторах
+	NetProto   bool `toml:"net_proto"`  // Сбор счётчиков сетевых протоколов (TCP/UDP)
+	NetIface   bool `toml:"net_iface"`  // Сбор статистики сетевых интерфейсов
+	RAID       bool `toml:"raid"`       // Сбор состояния программных RAID-массивов
+	// Инвентарь блочных устройств и связь дисков с точками монтирования
+	BlockDevices bool `toml:"block_devices"`
+	Daemon       bool `toml:"daemon"` // Внутренние метрики демона
+}
+
+// FDConfig настройки сбора статистики файловых дескрипторов.
+type FDConfig struct {
+	ThresholdPercent float64 `toml:"threshold_percent"` // Порог подсветки процесса (% от RLIMIT_NOFILE)
+	TopN             int     `toml:"top_n"`             // Количество процессов в ответе (помимо превысивших порог)
+}
+
+// EngineConfig настройки общего движка сбора, из замеров которого отвечают все запросы.
+type EngineConfig struct {
+	Resolution int `toml:"resolution"` // Наименьший период опроса подсистем, сек (0 - по [resolution])
+	Retention  int `toml:"retention"`  // Сколько хранить замеры, сек
+}
+
+// RequestConfig допустимые границы интервала N и периода M в запросах, сек.
+type RequestConfig struct {
+	MinInterval int32 `toml:"min_interval"`
+	MaxInterval int32 `toml:"max_interval"`
+	MinDuration int32 `toml:"min_duration"`
+	MaxDuration int32 `toml:"max_duration"`
+	// Нижние границы N и M в запросах с interval_ms/duration_ms, мс
+	MinIntervalMS int32 `toml:"min_interval_ms"`
+	MinDurationMS int32 `toml:"min_duration_ms"`
+}
+
+// ResolutionConfig базовое разрешение опроса подсистем, мс: с ним подсистему опрашивает общий
+// движок (но не чаще engine.resolution, если он задан), и поток не может запросить N меньше.
+type ResolutionConfig struct {
+	LoadAvg      int `toml:"load_avg"`
+	CPU          int `toml:"cpu"`
+	Disk         int `toml:"disk"`
+	Filesystem   int `toml:"filesystem"`
+	FD           int `toml:"fd"`
+	NetProto     int `toml:"net_proto"`
+	NetIface     int `toml:"net_iface"`
+	RAID         int `toml:"raid"`
+	BlockDevices int `toml:"block_devices"`
+	Daemon       int `toml:"daemon"`
+}
+
+// HistoryConfig настройки хранения истории значений в памяти.
+type HistoryConfig struct {
+	MemoryBudgetMB int          `toml:"memory_budget_mb"` // Ограничение памяти под историю, МБ (0 - без ограничения)
+	Tiers          []TierConfig `toml:"tiers"`            // Уровни хранения от подробного к грубому
+}
+
+// TierConfig уровень хранения истории: значения сворачиваются в интервалы resolution и хранятся retention.
+type TierConfig struct {
+	Resolution int `toml:"resolution"` // Размер интервала, сек
+	Retention  int `toml:"retention"`  // Время хранения, сек
+}
+
+// LifecycleConfig настройки отслеживания сущностей подсистем (устройств, точек монтирования).
+type LifecycleConfig struct {
+	Expire int `toml:"expire"` // Через сколько секунд отсутствия сущность считается удалённой
+}
+
+// KeepaliveConfig настройки keepalive gRPC-соединений, сек (0 - значение gRPC по умолчанию).
+type KeepaliveConfig struct {
+	Time                int  `toml:"time"`                  // Пинг клиента после стольких секунд тишины в соединении
+	Timeout             int  `toml:"timeout"`               // Ожидание ответа на пинг до закрытия соединения
+	MinTime             int  `toml:"min_time"`              // Минимальный допустимый период пингов от клиента
+	PermitWithoutStream bool `toml:"permit_without_stream"` // Разрешать пинги клиента без активных потоков
+}
+
+// CoverageConfig порог достоверности усреднённых значений.
+type CoverageConfig struct {
+	MinRatio float64 `toml:"min_ratio"` // Минимальная доля полученных замеров окна, ниже - значения устарели
+}
+
+// CommandsConfig ограничения внешних команд коллекторов.
+type CommandsConfig struct {
+	FilesystemTimeout int `toml:"filesystem_timeout"` // Срок выполнения каждого вызова df, сек
+	MaxOutputKB       int `toml:"max_output_kb"`      // Ограничение вывода команды, КБ
+}
+
+// SupervisorConfig перезапуск коллекторов после паники.
+type SupervisorConfig struct {
+	InitialBackoff int `toml:"initial_backoff"` // Задержка перед первым перезапуском, сек
+	MaxBackoff     int `toml:"max_backoff"`     // Предел удвоения задержки, сек
+	// Сколько секунд коллектор должен проработать без паники, чтобы задержка сбросилась,
+	// а подсистема перестала считаться деградированной
+	StableAfter int `toml:"stable_after"`
+}
+
+// BackpressureConfig очередь отправки сообщений каждому клиенту потока.
+type BackpressureConfig struct {
+	Queue      int    `toml:"queue"`       // Сообщений в очереди клиента
+	Policy     string `toml:"policy"`      // При переполнении: drop_oldest, latest или disconnect
+	MaxDropped int    `toml:"max_dropped"` // Для disconnect: после стольких отброшенных сообщений клиент отключается
 }
 
 // NewConfig создает конфигурацию по умолчанию.
@@ -40,6 +152,70 @@ func NewConfig() *Config {
 		Enabled: MetricsConfig{
 			LoadAvg: true, // По умолчанию включен только load average
 		},
+		FD: FDConfig{
+			ThresholdPercent: 80,
+			TopN:             10,
+		},
+		Engine: EngineConfig{
+			Resolution: 0,
+			Retention:  900,
+		},
+		Request: RequestConfig{
+			MinInterval: 1,
+			MaxInterval: 300,
+			MinDuration: 1,
+			MaxDuration: 3600,
+			// Опрос чаще раза в секунду - только по interval_ms
+			MinIntervalMS: 100,
+			MinDurationMS: 100,
+		},
+		Resolution: ResolutionConfig{
+			// Раз в секунду; опрос чаще включается для каждой подсистемы отдельно
+			LoadAvg:      1000,
+			CPU:          1000,
+			Disk:         1000,
+			Filesystem:   1000,
+			FD:           1000,
+			NetProto:     1000,
+			NetIface:     1000,
+			RAID:         1000,
+			BlockDevices: 1000,
+			Daemon:       1000,
+		},
+		History: HistoryConfig{
+			MemoryBudgetMB: 64,
+			Tiers: []TierConfig{
+				{Resolution: 1, Retention: 600},     // 1 секунда за 10 минут
+				{Resolution: 10, Retention: 21600},  // 10 секунд за 6 часов
+				{Resolution: 60, Retention: 604800}, // 1 минута за 7 дней
+			},
+		},
+		Lifecycle: LifecycleConfig{
+			Expire: 30,
+		},
+		Keepalive: KeepaliveConfig{
+			Time:                30,
+			Timeout:             10,
+			MinTime:             10,
+			PermitWithoutStream: true,
+		},
+		Coverage: CoverageConfig{
+			MinRatio: 0.5,
+		},
+		Commands: CommandsConfig{
+			FilesystemTimeout: 10,
+			MaxOutputKB:       1024,
+		},
+		Supervisor: SupervisorConfig{
+			InitialBackoff: 1,
+			MaxBackoff:     60,
+			StableAfter:    60,
+		},
+		Backpressure: BackpressureConfig{
+			Queue:      10,
+			Policy:     "drop_oldest",
+			MaxDropped: 100,
+		},
 	}
 }
 
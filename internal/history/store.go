@@ -0,0 +1,270 @@
+package history
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// Key - идентификатор временного ряда: подсистема, метрика и метка (устройство, интерфейс и т.п.).
+type Key struct {
+	Subsystem string
+	Metric    string
+	Label     string
+}
+
+// Point - свёртка значений метрики за интервал, начинающийся в Time.
+type Point struct {
+	Time  time.Time
+	Min   float64
+	Max   float64
+	Avg   float64
+	Count int
+}
+
+// Series - временной ряд с точками в порядке возрастания времени.
+type Series struct {
+	Key    Key
+	Points []Point
+}
+
+// Tier - уровень хранения: значения сворачиваются в интервалы Resolution и хранятся Retention.
+type Tier struct {
+	Resolution time.Duration
+	Retention  time.Duration
+}
+
+// bucket - накопитель значений одного интервала.
+type bucket struct {
+	start    time.Time
+	min, max float64
+	sum      float64
+	count    int
+}
+
+// Приблизительный объём памяти на один интервал и на запись ряда в map без строк ключа:
+// сам ключ, заголовок слайса и служебные байты корзины map.
+const (
+	bucketSize     = int(unsafe.Sizeof(bucket{}))
+	seriesOverhead = int(unsafe.Sizeof(Key{})+unsafe.Sizeof([]bucket{})) + 16
+)
+
+// seriesSize - приблизительный объём памяти ряда: запись в map, строки ключа и выделенный
+// под интервалы массив (по ёмкости, а не по числу интервалов).
+func seriesSize(key Key, buckets []bucket) int {
+	return seriesOverhead + len(key.Subsystem) + len(key.Metric) + len(key.Label) + cap(buckets)*bucketSize
+}
+
+// tier - уровень хранения с рядами интервалов.
+type tier struct {
+	Tier
+	series map[Key][]bucket
+}
+
+// oldest - начало самого старого интервала уровня; нулевое время, если уровень пуст.
+func (t *tier) oldest() time.Time {
+	var oldest time.Time
+	for _, buckets := range t.series {
+		if oldest.IsZero() || buckets[0].start.Before(oldest) {
+			oldest = buckets[0].start
+		}
+	}
+	return oldest
+}
+
+// Store - хранилище временных рядов в памяти с уровнями разного разрешения.
+// Каждое значение попадает во все уровни; при превышении бюджета памяти
+// в первую очередь отбрасываются самые старые интервалы самого грубого уровня.
+type Store struct {
+	mu       sync.RWMutex
+	tiers    []*tier
+	maxBytes int // 0 - без ограничения
+	bytes    int
+	last     time.Time // Время последнего добавления
+}
+
+// NewStore - создаёт хранилище с уровнями tiers и бюджетом памяти budget байт (0 - без ограничения).
+func NewStore(tiers []Tier, budget int) *Store {
+	sorted := append([]Tier{}, tiers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Resolution < sorted[j].Resolution })
+
+	s := &Store{maxBytes: budget}
+	for _, t := range sorted {
+		if t.Resolution <= 0 || t.Retention <= 0 {
+			continue
+		}
+		s.tiers = append(s.tiers, &tier{Tier: t, series: make(map[Key][]bucket)})
+	}
+	return s
+}
+
+// Append - добавляет значения всех переданных рядов, полученные в момент at.
+func (s *Store) Append(at time.Time, values map[Key]float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if at.After(s.last) {
+		s.last = at
+	}
+
+	for _, t := range s.tiers {
+		start := at.Truncate(t.Resolution)
+		for key, value := range values {
+			buckets := t.series[key]
+			if n := len(buckets); n > 0 && buckets[n-1].start.Equal(start) {
+				b := &buckets[n-1]
+				b.min = math.Min(b.min, value)
+				b.max = math.Max(b.max, value)
+				b.sum += value
+				b.count++
+				continue
+			}
+			s.set(t, key, append(buckets, bucket{start: start, min: value, max: value, sum: value, count: 1}))
+		}
+
+		s.trim(t, at.Add(-t.Retention), false)
+	}
+
+	s.enforceBudget()
+}
+
+// set - заменяет интервалы ряда уровня, учитывая изменение занятой памяти; nil удаляет ряд.
+func (s *Store) set(t *tier, key Key, buckets []bucket) {
+	if old, ok := t.series[key]; ok {
+		s.bytes -= seriesSize(key, old)
+	}
+	if buckets == nil {
+		delete(t.series, key)
+		return
+	}
+	t.series[key] = buckets
+	s.bytes += seriesSize(key, buckets)
+}
+
+// trim - отбрасывает интервалы уровня, начавшиеся раньше keep; ряды без интервалов удаляет.
+// Оставшиеся интервалы сдвигаются в начало массива, и место отброшенных занимают новые.
+// При shrink массив ужимается до оставшихся интервалов, освобождая память.
+func (s *Store) trim(t *tier, keep time.Time, shrink bool) {
+	for key, buckets := range t.series {
+		cut := sort.Search(len(buckets), func(i int) bool { return !buckets[i].start.Before(keep) })
+		switch {
+		case cut == len(buckets):
+			s.set(t, key, nil)
+		case cut > 0 && shrink:
+			s.set(t, key, slices.Clone(buckets[cut:]))
+		case cut > 0:
+			s.set(t, key, buckets[:copy(buckets, buckets[cut:])])
+		}
+	}
+}
+
+// enforceBudget - укладывает хранилище в бюджет, сокращая глубину начиная с самого грубого уровня.
+func (s *Store) enforceBudget() {
+	for i := len(s.tiers) - 1; i >= 0 && s.maxBytes > 0 && s.bytes > s.maxBytes; i-- {
+		t := s.tiers[i]
+		for s.bytes > s.maxBytes && len(t.series) > 0 {
+			s.trim(t, t.oldest().Add(t.Resolution), true)
+		}
+	}
+}
+
+// Query - возвращает ряды подсистемы за период [from, to] и разрешение возвращённых точек.
+// Пустые metric и label означают любые. Используется самый подробный уровень, хранящий весь период,
+// а если такого нет - уровень, хранящий самые старые интервалы.
+// При step > 0 интервалы дополнительно сворачиваются по шагу step, отсчитываемому от from.
+func (s *Store) Query(subsystem, metric, label string, from, to time.Time, step time.Duration) ([]Series, time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.tiers) == 0 {
+		return nil, 0
+	}
+	// Глубина уровня - по самому старому интервалу, который он действительно хранит:
+	// после старта и при нехватке бюджета она меньше retention
+	t := s.tiers[0]
+	reach := t.oldest()
+	for _, candidate := range s.tiers {
+		oldest := candidate.oldest()
+		if oldest.IsZero() {
+			continue
+		}
+		if !from.Before(oldest) {
+			t = candidate
+			break
+		}
+		if reach.IsZero() || oldest.Before(reach) {
+			t, reach = candidate, oldest
+		}
+	}
+
+	resolution := t.Resolution
+	if step > resolution {
+		resolution = step
+	}
+
+	var result []Series
+	for key, buckets := range t.series {
+		if key.Subsystem != subsystem || (metric != "" && key.Metric != metric) || (label != "" && key.Label != label) {
+			continue
+		}
+
+		lo := sort.Search(len(buckets), func(i int) bool { return !buckets[i].start.Before(from) })
+		hi := sort.Search(len(buckets), func(i int) bool { return buckets[i].start.After(to) })
+		if lo >= hi {
+			continue
+		}
+
+		selected := buckets[lo:hi]
+		if resolution > t.Resolution {
+			selected = rollup(selected, from, step)
+		}
+		points := make([]Point, 0, len(selected))
+		for _, b := range selected {
+			points = append(points, b.point())
+		}
+		result = append(result, Series{Key: key, Points: points})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i].Key, result[j].Key
+		if a.Metric != b.Metric {
+			return a.Metric < b.Metric
+		}
+		return a.Label < b.Label
+	})
+
+	return result, resolution
+}
+
+// rollup - сворачивает интервалы в более крупные длиной step.
+func rollup(buckets []bucket, from time.Time, step time.Duration) []bucket {
+	var result []bucket
+	for _, b := range buckets {
+		start := from.Add(b.start.Sub(from) / step * step)
+		if n := len(result); n > 0 && result[n-1].start.Equal(start) {
+			r := &result[n-1]
+			r.min = math.Min(r.min, b.min)
+			r.max = math.Max(r.max, b.max)
+			r.sum += b.sum
+			r.count += b.count
+			continue
+		}
+		b.start = start
+		result = append(result, b)
+	}
+	return result
+}
+
+// point - итоговые значения интервала.
+func (b bucket) point() Point {
+	return Point{
+		Time:  b.start,
+		Min:   b.min,
+		Max:   b.max,
+		Avg:   b.sum / float64(b.count),
+		Count: b.count,
+	}
+}
@@ -0,0 +1,198 @@
+package history
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// secondTier - один уровень с разрешением 1 секунда.
+var secondTier = []Tier{{Resolution: time.Second, Retention: time.Hour}}
+
+// grown - массив из n интервалов той ёмкости, до которой его дорастит append.
+func grown(n int) []bucket {
+	var buckets []bucket
+	for range n {
+		buckets = append(buckets, bucket{})
+	}
+	return buckets
+}
+
+func TestStoreQuery(t *testing.T) {
+	store := NewStore(secondTier, 0)
+	load := Key{Subsystem: "load_avg", Metric: "load_1min"}
+	sda := Key{Subsystem: "disk", Metric: "tps", Label: "sda"}
+	sdb := Key{Subsystem: "disk", Metric: "tps", Label: "sdb"}
+
+	for i := range 6 {
+		store.Append(t0.Add(time.Duration(i)*time.Second), map[Key]float64{
+			load: float64(i),
+			sda:  10,
+			sdb:  20,
+		})
+	}
+
+	tests := []struct {
+		name       string
+		subsystem  string
+		metric     string
+		label      string
+		from, to   time.Time
+		step       time.Duration
+		wantSeries int
+		wantValues []float64 // Средние значения первого ряда
+	}{
+		{"raw range", "load_avg", "load_1min", "", t0.Add(time.Second), t0.Add(3 * time.Second), 0, 1, []float64{1, 2, 3}},
+		{"downsampled", "load_avg", "", "", t0, t0.Add(time.Minute), 2 * time.Second, 1, []float64{0.5, 2.5, 4.5}},
+		{"all labels", "disk", "tps", "", t0, t0.Add(time.Minute), 0, 2, []float64{10, 10, 10, 10, 10, 10}},
+		{"one label", "disk", "", "sdb", t0, t0.Add(time.Second), 0, 1, []float64{20, 20}},
+		{"empty range", "load_avg", "", "", t0.Add(time.Hour), t0.Add(2 * time.Hour), 0, 0, nil},
+		{"unknown subsystem", "cpu", "", "", t0, t0.Add(time.Minute), 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, _ := store.Query(tt.subsystem, tt.metric, tt.label, tt.from, tt.to, tt.step)
+			if len(series) != tt.wantSeries {
+				t.Fatalf("Query() got %d series, want %d", len(series), tt.wantSeries)
+			}
+			if tt.wantSeries == 0 {
+				return
+			}
+			points := series[0].Points
+			if len(points) != len(tt.wantValues) {
+				t.Fatalf("Query() got %d points, want %d", len(points), len(tt.wantValues))
+			}
+			for i, want := range tt.wantValues {
+				if points[i].Avg != want {
+					t.Errorf("Query() point #%d = %v, want %v", i, points[i].Avg, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreRetention(t *testing.T) {
+	store := NewStore([]Tier{{Resolution: time.Second, Retention: 2 * time.Second}}, 0)
+	cpu := Key{Subsystem: "cpu", Metric: "idle"}
+	sdc := Key{Subsystem: "disk", Metric: "tps", Label: "sdc"}
+
+	store.Append(t0, map[Key]float64{cpu: 1, sdc: 1})
+	for i := 1; i <= 4; i++ {
+		store.Append(t0.Add(time.Duration(i)*time.Second), map[Key]float64{cpu: float64(i)})
+	}
+
+	series, _ := store.Query("cpu", "idle", "", t0.Add(2*time.Second), t0.Add(time.Minute), 0)
+	if len(series) != 1 || len(series[0].Points) != 3 || series[0].Points[0].Avg != 2 {
+		t.Errorf("Query() after retention = %+v, want points 2..4", series)
+	}
+	// Ряд пропавшего устройства удаляется целиком
+	if series, _ := store.Query("disk", "", "", t0.Add(2*time.Second), t0.Add(time.Minute), 0); len(series) != 0 {
+		t.Errorf("Query() expired series = %+v, want none", series)
+	}
+}
+
+func TestStoreTiers(t *testing.T) {
+	store := NewStore([]Tier{
+		{Resolution: time.Minute, Retention: time.Hour},
+		{Resolution: time.Second, Retention: 10 * time.Second},
+	}, 0)
+	cpu := Key{Subsystem: "cpu", Metric: "user"}
+
+	// Две минуты значений 0..119 раз в секунду
+	for i := range 120 {
+		store.Append(t0.Add(time.Duration(i)*time.Second), map[Key]float64{cpu: float64(i)})
+	}
+	last := t0.Add(119 * time.Second)
+
+	// Недавний период отдаётся с секундным разрешением
+	series, resolution := store.Query("cpu", "user", "", last.Add(-5*time.Second), last, 0)
+	if resolution != time.Second || len(series) != 1 || len(series[0].Points) != 6 {
+		t.Fatalf("Query() recent = %+v at %v, want 6 points at 1s", series, resolution)
+	}
+
+	// Период старше секундного уровня берётся из минутного со свёрткой min/max/avg/count
+	series, resolution = store.Query("cpu", "user", "", t0, last, 0)
+	if resolution != time.Minute || len(series) != 1 || len(series[0].Points) != 2 {
+		t.Fatalf("Query() long range = %+v at %v, want 2 points at 1m", series, resolution)
+	}
+	want := Point{Time: t0, Min: 0, Max: 59, Avg: 29.5, Count: 60}
+	if got := series[0].Points[0]; got != want {
+		t.Errorf("Query() first minute = %+v, want %+v", got, want)
+	}
+
+	// При свёртке по шагу разрешение ответа - шаг, а не разрешение уровня
+	series, resolution = store.Query("cpu", "user", "", last.Add(-9*time.Second), last, 5*time.Second)
+	if resolution != 5*time.Second || len(series) != 1 || len(series[0].Points) != 2 {
+		t.Errorf("Query() with step = %+v at %v, want 2 points at 5s", series, resolution)
+	}
+}
+
+func TestStoreBudget(t *testing.T) {
+	mem := Key{Subsystem: "fd", Metric: "allocated"}
+	// Бюджет на 11 секундных интервалов (границы retention включительно) и 4 минутных
+	// вместе с памятью под записи рядов
+	budget := seriesSize(mem, grown(11)) + seriesSize(mem, grown(4))
+	store := NewStore([]Tier{
+		{Resolution: time.Second, Retention: 10 * time.Second},
+		{Resolution: time.Minute, Retention: 24 * time.Hour},
+	}, budget)
+
+	for i := range 20 * 60 {
+		store.Append(t0.Add(time.Duration(i)*time.Second), map[Key]float64{mem: 1})
+	}
+
+	if store.bytes > budget {
+		t.Errorf("Store holds %d bytes, want at most %d", store.bytes, budget)
+	}
+	last := t0.Add(20*time.Minute - time.Second)
+	// Подробный уровень не страдает, сокращается глубина грубого
+	if series, _ := store.Query("fd", "", "", last.Add(-9*time.Second), last, 0); len(series[0].Points) != 10 {
+		t.Errorf("Query() recent points = %d, want 10", len(series[0].Points))
+	}
+	if series, _ := store.Query("fd", "", "", t0, last, 0); len(series[0].Points) != 4 {
+		t.Errorf("Query() minute points = %d, want 4", len(series[0].Points))
+	}
+}
+
+func TestStoreQueryTrimmedTier(t *testing.T) {
+	cpu := Key{Subsystem: "cpu", Metric: "user"}
+	// Бюджет оставляет грубому уровню меньше истории, чем хранит подробный
+	store := NewStore([]Tier{
+		{Resolution: time.Second, Retention: 10 * time.Minute},
+		{Resolution: time.Minute, Retention: 24 * time.Hour},
+	}, seriesSize(cpu, grown(601))+seriesSize(cpu, grown(2)))
+
+	for i := range 20 * 60 {
+		store.Append(t0.Add(time.Duration(i)*time.Second), map[Key]float64{cpu: 1})
+	}
+	last := t0.Add(20*time.Minute - time.Second)
+
+	// Период старше обоих уровней отдаётся из того, что хранит больше истории
+	series, resolution := store.Query("cpu", "user", "", t0, last, 0)
+	if resolution != time.Second || len(series) != 1 || len(series[0].Points) != 601 {
+		t.Errorf("Query() long range at %v, want 601 points at 1s", resolution)
+	}
+}
+
+func TestStoreBudgetSeries(t *testing.T) {
+	// Каждый ряд занимает память и без интервалов: бюджет на 3 ряда по одному интервалу
+	budget := 3 * seriesSize(Key{Subsystem: "disk", Metric: "tps", Label: "sd0"}, make([]bucket, 1))
+	store := NewStore(secondTier, budget)
+
+	for i := range 10 {
+		key := Key{Subsystem: "disk", Metric: "tps", Label: fmt.Sprintf("sd%d", i)}
+		store.Append(t0.Add(time.Duration(i)*time.Second), map[Key]float64{key: 1})
+	}
+
+	if store.bytes > budget {
+		t.Errorf("Store holds %d bytes, want at most %d", store.bytes, budget)
+	}
+	// Остаются самые свежие ряды
+	series, _ := store.Query("disk", "", "", t0, t0.Add(time.Minute), 0)
+	if len(series) != 3 || series[0].Key.Label != "sd7" {
+		t.Errorf("Query() series = %+v, want sd7..sd9", series)
+	}
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/history"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// Aggregate - сворачивает значения окна в порядке получения заданной функцией.
+func Aggregate(fn pb.Aggregation, values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	switch fn {
+	case pb.Aggregation_AGGREGATION_MIN:
+		return round(slices.Min(values))
+	case pb.Aggregation_AGGREGATION_MAX:
+		return round(slices.Max(values))
+	case pb.Aggregation_AGGREGATION_P50:
+		return round(percentile(values, 50))
+	case pb.Aggregation_AGGREGATION_P95:
+		return round(percentile(values, 95))
+	case pb.Aggregation_AGGREGATION_P99:
+		return round(percentile(values, 99))
+	case pb.Aggregation_AGGREGATION_STDDEV:
+		return round(stddev(values))
+	case pb.Aggregation_AGGREGATION_LAST:
+		return round(values[len(values)-1])
+	default:
+		return round(mean(values))
+	}
+}
+
+// mean - среднее арифметическое.
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// stddev - стандартное отклонение по всем значениям окна.
+func stddev(values []float64) float64 {
+	avg := mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - avg) * (v - avg)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// percentile - перцентиль p с линейной интерполяцией между соседними значениями.
+func percentile(values []float64, p float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+// aggregateSeries - считает агрегаты fns по рядам значений подсистемы. Ряды упорядочены
+// по метрике и метке, внутри ряда агрегаты идут в порядке запроса.
+func aggregateSeries(series map[history.Key][]float64, fns []pb.Aggregation) []*pb.Aggregate {
+	keys := make([]history.Key, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b history.Key) int {
+		return cmp.Or(cmp.Compare(a.Metric, b.Metric), cmp.Compare(a.Label, b.Label))
+	})
+
+	result := make([]*pb.Aggregate, 0, len(keys)*len(fns))
+	for _, key := range keys {
+		values := series[key]
+		for _, fn := range fns {
+			result = append(result, &pb.Aggregate{
+				Subsystem: key.Subsystem,
+				Metric:    key.Metric,
+				Label:     key.Label,
+				Function:  fn,
+				Value:     Aggregate(fn, values),
+				Samples:   int32(len(values)), //nolint:gosec
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+func TestAggregate(t *testing.T) {
+	// Короткий всплеск, который теряется в среднем
+	values := []float64{10, 10, 10, 10, 10, 10, 10, 10, 10, 100, 20}
+
+	tests := []struct {
+		fn   pb.Aggregation
+		want float64
+	}{
+		{pb.Aggregation_AGGREGATION_MEAN, 19.09},
+		{pb.Aggregation_AGGREGATION_MIN, 10},
+		{pb.Aggregation_AGGREGATION_MAX, 100},
+		{pb.Aggregation_AGGREGATION_P50, 10},
+		{pb.Aggregation_AGGREGATION_P95, 60},
+		{pb.Aggregation_AGGREGATION_P99, 92},
+		{pb.Aggregation_AGGREGATION_STDDEV, 25.75},
+		{pb.Aggregation_AGGREGATION_LAST, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fn.String(), func(t *testing.T) {
+			if got := Aggregate(tt.fn, values); got != tt.want {
+				t.Errorf("Aggregate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := Aggregate(pb.Aggregation_AGGREGATION_MAX, nil); got != 0 {
+		t.Errorf("Aggregate() of empty window = %v, want 0", got)
+	}
+}
+
+// TestEngineAggregates - проверяет агрегаты по замерам окна, в том числе по скоростям счётчиков.
+func TestEngineAggregates(t *testing.T) {
+	var at time.Time
+	load, segs := 0.0, 0.0
+	e := &Engine{resolution: time.Second, retention: time.Minute}
+	addSource(e, true, &source[model.LoadAvgRecord]{
+		name: SubsystemLoadAvg,
+		get: func() (model.LoadAvgRecord, error) {
+			load++
+			return model.LoadAvgRecord{Load1min: load}, nil
+		},
+		aggregate: averageLoadAvg,
+	})
+	addSource(e, true, &source[model.NetProtoStats]{
+		name: SubsystemNetProto,
+		get: func() (model.NetProtoStats, error) {
+			segs += load * 10
+			return model.NetProtoStats{Time: at, Counters: map[string]float64{"Tcp.InSegs": segs}}, nil
+		},
+		aggregate: func(window []model.NetProtoStats) *pb.StatsResponse {
+			return &pb.StatsResponse{NetProtoStats: netProtoRates(window)}
+		},
+		counter: true,
+	})
+
+	// load_1min: 1..5, скорость InSegs между замерами: 20, 30, 40, 50
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		at = t0.Add(time.Duration(i) * time.Second)
+		for _, src := range e.sources {
+			if err := src.collect(at); err != nil {
+				t.Fatalf("collect() unexpected error: %v", err)
+			}
+		}
+	}
+
+	fns := []pb.Aggregation{pb.Aggregation_AGGREGATION_MIN, pb.Aggregation_AGGREGATION_MAX}
+	requests := []*pb.AggregationRequest{
+		{Subsystem: SubsystemLoadAvg, Functions: fns},
+		{Subsystem: SubsystemNetProto, Functions: fns},
+		{Subsystem: SubsystemCPU, Functions: fns}, // Движок не собирает
+	}
+	selected := config.MetricsConfig{LoadAvg: true, NetProto: true, CPU: true}
+
+	got := map[string]float64{}
+	for _, a := range e.Aggregates(at, 10*time.Second, selected, requests) {
+		got[a.GetMetric()+" "+a.GetFunction().String()] = a.GetValue()
+	}
+	want := map[string]float64{
+		"load_1min AGGREGATION_MIN":  1,
+		"load_1min AGGREGATION_MAX":  5,
+		"Tcp.InSegs AGGREGATION_MIN": 20,
+		"Tcp.InSegs AGGREGATION_MAX": 50,
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("Aggregates() %s = %v, want %v", key, got[key], value)
+		}
+	}
+
+	// Не выбранная подсистема не агрегируется
+	if aggs := e.Aggregates(at, 10*time.Second, config.MetricsConfig{LoadAvg: true}, requests[1:2]); len(aggs) != 0 {
+		t.Errorf("Aggregates() for unselected subsystem = %v, want none", aggs)
+	}
+}
@@ -0,0 +1,258 @@
+package metrics
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// blockDevicesToPb - преобразует инвентарь в protobuf-сообщения.
+func blockDevicesToPb(devices []model.BlockDevice) []*pb.BlockDevice {
+	result := make([]*pb.BlockDevice, 0, len(devices))
+	for _, d := range devices {
+		result = append(result, &pb.BlockDevice{
+			Name:        d.Name,
+			Dev:         d.Dev,
+			Type:        d.Type,
+			Parent:      d.Parent,
+			SizeBytes:   d.SizeBytes,
+			Rotational:  d.Rotational,
+			Model:       d.Model,
+			Scheduler:   d.Scheduler,
+			DmName:      d.DMName,
+			Partitions:  d.Partitions,
+			Holders:     d.Holders,
+			Slaves:      d.Slaves,
+			MountedAt:   d.MountedAt,
+			Mountpoints: d.Mountpoints,
+		})
+	}
+	return result
+}
+
+// JoinBlockDevices - связывает строки дисков и файловых систем с инвентарём блочных устройств:
+// диск получает обслуживаемые точки монтирования, файловая система - устройства, на которых она лежит.
+func JoinBlockDevices(stats *pb.StatsResponse) {
+	byName := make(map[string]*pb.BlockDevice, len(stats.GetBlockDevices()))
+	byMount := make(map[string]*pb.BlockDevice)
+	for _, d := range stats.GetBlockDevices() {
+		byName[d.GetName()] = d
+		for _, mp := range d.GetMountedAt() {
+			byMount[mp] = d
+		}
+	}
+
+	for _, disk := range stats.GetDiskStats() {
+		if d, ok := byName[disk.GetDevice()]; ok {
+			disk.Mountpoints = d.GetMountpoints()
+		}
+	}
+
+	for _, fs := range stats.GetFilesystemStats() {
+		d, ok := byMount[fs.GetMountpoint()]
+		if !ok {
+			continue // tmpfs, сетевые ФС и т.п.
+		}
+		fs.BackingDevices = backingChain(d, byName)
+	}
+}
+
+// backingChain - возвращает устройство и все устройства под ним (slaves dm/md, диск раздела).
+func backingChain(device *pb.BlockDevice, byName map[string]*pb.BlockDevice) []string {
+	var chain []string
+	seen := make(map[string]bool)
+	queue := []*pb.BlockDevice{device}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		if seen[d.GetName()] {
+			continue
+		}
+		seen[d.GetName()] = true
+		chain = append(chain, d.GetName())
+
+		below := append([]string{}, d.GetSlaves()...)
+		if d.GetParent() != "" {
+			below = append(below, d.GetParent())
+		}
+		for _, name := range below {
+			if next, ok := byName[name]; ok {
+				queue = append(queue, next)
+			}
+		}
+	}
+	return chain
+}
+
+// GetBlockDevices - строит инвентарь блочных устройств из /sys/block и /proc/self/mountinfo.
+func GetBlockDevices(reader FSReader) ([]model.BlockDevice, error) {
+	names, err := reader.ReadDir("/sys/block")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read /sys/block: %w", err)
+	}
+
+	var devices []model.BlockDevice
+	for _, name := range names {
+		dir := filepath.Join("/sys/block", name)
+		disk, ok := readBlockDevice(reader, dir, name)
+		if !ok || disk.SizeBytes == 0 {
+			continue // Пустые loop/ram-устройства
+		}
+		disk.Type = blockDeviceType(name)
+		disk.Rotational = readSysfs(reader, filepath.Join(dir, "queue/rotational")) == "1"
+		disk.Model = readSysfs(reader, filepath.Join(dir, "device/model"))
+		disk.Scheduler = selectedScheduler(readSysfs(reader, filepath.Join(dir, "queue/scheduler")))
+		disk.DMName = readSysfs(reader, filepath.Join(dir, "dm/name"))
+		disk.Slaves, _ = reader.ReadDir(filepath.Join(dir, "slaves"))
+
+		// Разделы - подкаталоги с именем, начинающимся с имени диска
+		entries, _ := reader.ReadDir(dir)
+		var partitions []model.BlockDevice
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry, name) {
+				continue
+			}
+			part, ok := readBlockDevice(reader, filepath.Join(dir, entry), entry)
+			if !ok {
+				continue
+			}
+			part.Type = "part"
+			part.Parent = name
+			part.Rotational = disk.Rotational
+			disk.Partitions = append(disk.Partitions, entry)
+			partitions = append(partitions, part)
+		}
+
+		devices = append(devices, disk)
+		devices = append(devices, partitions...)
+	}
+
+	if err := mapMounts(reader, devices); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// readBlockDevice - читает общие для диска и раздела атрибуты (dev, size, holders).
+func readBlockDevice(reader FSReader, dir, name string) (model.BlockDevice, bool) {
+	dev := readSysfs(reader, filepath.Join(dir, "dev"))
+	if dev == "" {
+		return model.BlockDevice{}, false
+	}
+	sectors, _ := strconv.ParseFloat(readSysfs(reader, filepath.Join(dir, "size")), 64)
+	holders, _ := reader.ReadDir(filepath.Join(dir, "holders"))
+
+	return model.BlockDevice{
+		Name:      name,
+		Dev:       dev,
+		SizeBytes: sectors * 512, // size всегда в 512-байтных секторах
+		Holders:   holders,
+	}, true
+}
+
+// mapMounts - заполняет точки монтирования устройств по major:minor из /proc/self/mountinfo.
+func mapMounts(reader FileReader, devices []model.BlockDevice) error {
+	data, err := reader.ReadFile("/proc/self/mountinfo")
+	if err != nil {
+		return fmt.Errorf("failed to read mountinfo: %w", err)
+	}
+
+	byDev := make(map[string]int, len(devices))
+	byName := make(map[string]int, len(devices))
+	for i, d := range devices {
+		byDev[d.Dev] = i
+		byName[d.Name] = i
+	}
+
+	// Формат: ID parentID major:minor root mountpoint ...
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		if i, ok := byDev[fields[2]]; ok {
+			devices[i].MountedAt = append(devices[i].MountedAt, unescapeMountinfo(fields[4]))
+		}
+	}
+
+	// Устройство обслуживает свои точки монтирования, точки своих разделов и устройств над ним (holders)
+	var served func(i int, seen map[int]bool) []string
+	served = func(i int, seen map[int]bool) []string {
+		if seen[i] {
+			return nil
+		}
+		seen[i] = true
+		mounts := append([]string{}, devices[i].MountedAt...)
+		for _, name := range append(append([]string{}, devices[i].Partitions...), devices[i].Holders...) {
+			if j, ok := byName[name]; ok {
+				mounts = append(mounts, served(j, seen)...)
+			}
+		}
+		return mounts
+	}
+	for i := range devices {
+		mounts := served(i, make(map[int]bool))
+		slices.Sort(mounts)
+		devices[i].Mountpoints = slices.Compact(mounts)
+	}
+
+	return nil
+}
+
+// blockDeviceType - тип устройства по имени в /sys/block.
+func blockDeviceType(name string) string {
+	switch {
+	case strings.HasPrefix(name, "dm-"):
+		return "dm"
+	case strings.HasPrefix(name, "md"):
+		return "md"
+	case strings.HasPrefix(name, "loop"):
+		return "loop"
+	default:
+		return "disk"
+	}
+}
+
+// selectedScheduler - выбирает активный планировщик из строки вида "mq-deadline [none]".
+func selectedScheduler(line string) string {
+	for _, s := range strings.Fields(line) {
+		if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+			return strings.Trim(s, "[]")
+		}
+	}
+	return line
+}
+
+// unescapeMountinfo - раскрывает восьмеричные escape-последовательности (\040 - пробел).
+func unescapeMountinfo(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// readSysfs - читает значение атрибута sysfs, пустая строка при ошибке.
+func readSysfs(reader FileReader, path string) string {
+	data, err := reader.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"slices"
+	"testing"
+
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// blockFS - sda с двумя разделами, sda2 под LVM (dm-0), пустой loop0.
+var blockFS = MockFS{
+	Files: map[string][]byte{
+		"/sys/block/sda/dev":              []byte("8:0\n"),
+		"/sys/block/sda/size":             []byte("2097152\n"),
+		"/sys/block/sda/queue/rotational": []byte("1\n"),
+		"/sys/block/sda/queue/scheduler":  []byte("none [mq-deadline]\n"),
+		"/sys/block/sda/device/model":     []byte("ST1000DM010\n"),
+		"/sys/block/sda/sda1/dev":         []byte("8:1\n"),
+		"/sys/block/sda/sda1/size":        []byte("1024\n"),
+		"/sys/block/sda/sda2/dev":         []byte("8:2\n"),
+		"/sys/block/sda/sda2/size":        []byte("2000000\n"),
+		"/sys/block/dm-0/dev":             []byte("253:0\n"),
+		"/sys/block/dm-0/size":            []byte("2000000\n"),
+		"/sys/block/dm-0/dm/name":         []byte("vg-root\n"),
+		"/sys/block/loop0/dev":            []byte("7:0\n"),
+		"/sys/block/loop0/size":           []byte("0\n"),
+		"/proc/self/mountinfo": []byte(`23 28 0:22 / /proc rw,relatime - proc proc rw
+28 1 253:0 / / rw,relatime - ext4 /dev/mapper/vg-root rw
+29 28 8:1 / /boot\040efi rw,relatime - vfat /dev/sda1 rw
+`),
+	},
+	Dirs: map[string][]string{
+		"/sys/block":                  {"dm-0", "loop0", "sda"},
+		"/sys/block/sda":              {"dev", "device", "holders", "queue", "sda1", "sda2", "size"},
+		"/sys/block/sda/sda2/holders": {"dm-0"},
+		"/sys/block/dm-0/slaves":      {"sda2"},
+	},
+}
+
+func TestGetBlockDevices(t *testing.T) {
+	devices, err := GetBlockDevices(blockFS)
+	if err != nil {
+		t.Fatalf("GetBlockDevices() unexpected error: %v", err)
+	}
+
+	names := make([]string, 0, len(devices))
+	for _, d := range devices {
+		names = append(names, d.Name)
+	}
+	if !slices.Equal(names, []string{"dm-0", "sda", "sda1", "sda2"}) {
+		t.Fatalf("GetBlockDevices() devices = %v, want [dm-0 sda sda1 sda2]", names)
+	}
+
+	dm, sda, sda1 := devices[0], devices[1], devices[2]
+	if dm.Type != "dm" || dm.DMName != "vg-root" || !slices.Equal(dm.Slaves, []string{"sda2"}) ||
+		!slices.Equal(dm.MountedAt, []string{"/"}) {
+		t.Errorf("GetBlockDevices() dm-0 = %+v", dm)
+	}
+	if sda.Type != "disk" || !sda.Rotational || sda.Scheduler != "mq-deadline" || sda.Model != "ST1000DM010" ||
+		sda.SizeBytes != 1<<30 || !slices.Equal(sda.Partitions, []string{"sda1", "sda2"}) {
+		t.Errorf("GetBlockDevices() sda = %+v", sda)
+	}
+	// Диск обслуживает точки монтирования своих разделов и LVM поверх них
+	if !slices.Equal(sda.Mountpoints, []string{"/", "/boot efi"}) {
+		t.Errorf("GetBlockDevices() sda mountpoints = %v, want [/ /boot efi]", sda.Mountpoints)
+	}
+	if sda1.Type != "part" || sda1.Parent != "sda" || !slices.Equal(sda1.MountedAt, []string{"/boot efi"}) {
+		t.Errorf("GetBlockDevices() sda1 = %+v", sda1)
+	}
+
+	if _, err := GetBlockDevices(MockFS{}); err == nil {
+		t.Errorf("GetBlockDevices() error = nil, want error for missing /sys/block")
+	}
+}
+
+func TestJoinBlockDevices(t *testing.T) {
+	devices, err := GetBlockDevices(blockFS)
+	if err != nil {
+		t.Fatalf("GetBlockDevices() unexpected error: %v", err)
+	}
+
+	stats := &pb.StatsResponse{
+		DiskStats: []*pb.DiskStats{{Device: "sda"}, {Device: "dm-0"}, {Device: "sdz"}},
+		FilesystemStats: []*pb.FilesystemStats{
+			{Filesystem: "/dev/mapper/vg-root", Mountpoint: "/"},
+			{Filesystem: "tmpfs", Mountpoint: "/run"},
+		},
+		BlockDevices: blockDevicesToPb(devices),
+	}
+	JoinBlockDevices(stats)
+
+	if got := stats.DiskStats[0].Mountpoints; !slices.Equal(got, []string{"/", "/boot efi"}) {
+		t.Errorf("JoinBlockDevices() sda mountpoints = %v", got)
+	}
+	if got := stats.DiskStats[1].Mountpoints; !slices.Equal(got, []string{"/"}) {
+		t.Errorf("JoinBlockDevices() dm-0 mountpoints = %v", got)
+	}
+	if got := stats.DiskStats[2].Mountpoints; len(got) != 0 {
+		t.Errorf("JoinBlockDevices() unknown device mountpoints = %v, want none", got)
+	}
+	if got := stats.FilesystemStats[0].BackingDevices; !slices.Equal(got, []string{"dm-0", "sda2", "sda"}) {
+		t.Errorf("JoinBlockDevices() / backing devices = %v, want [dm-0 sda2 sda]", got)
+	}
+	if got := stats.FilesystemStats[1].BackingDevices; len(got) != 0 {
+		t.Errorf("JoinBlockDevices() tmpfs backing devices = %v, want none", got)
+	}
+}
+
+func TestUnescapeMountinfo(t *testing.T) {
+	tests := map[string]string{
+		`/mnt/a\040b`: "/mnt/a b",
+		`/plain`:      "/plain",
+		`/tail\04`:    `/tail\04`,
+	}
+	for in, want := range tests {
+		if got := unescapeMountinfo(in); got != want {
+			t.Errorf("unescapeMountinfo(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
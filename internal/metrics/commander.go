@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Ошибки выполнения внешних команд.
+var (
+	ErrCommandTimeout = errors.New("command timed out")
+	ErrOutputLimit    = errors.New("command output exceeds limit")
+)
+
+const (
+	defaultMaxOutput = 1 << 20 // Ограничение вывода команды по умолчанию, байт
+	maxStderr        = 4 << 10 // Сколько stderr сохранять для текста ошибки, байт
+	// Сколько ждать закрытия вывода после завершения команды: потомок, унаследовавший
+	// дескрипторы, не должен держать коллектор
+	commandWaitDelay = time.Second
+)
+
+// RunContext - выполняет команду с LC_ALL=C, чтобы формат чисел и дат не зависел от локали.
+// По истечении срока контекста завершается вся группа процессов команды и возвращается
+// ErrCommandTimeout; вывод сверх MaxOutput отбрасывается с ошибкой ErrOutputLimit.
+func (r RealCommander) RunContext(ctx context.Context, name string, args ...string) ([]byte, error) {
+	limit := r.MaxOutput
+	if limit <= 0 {
+		limit = defaultMaxOutput
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	stdout := &limitedBuffer{limit: limit}
+	stderr := &limitedBuffer{limit: maxStderr}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = commandWaitDelay
+	setProcessGroup(cmd)
+
+	if err := cmd.Run(); err != nil {
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return nil, fmt.Errorf("%s: %w", name, ErrCommandTimeout)
+		case ctx.Err() != nil:
+			return nil, fmt.Errorf("%s: %w", name, ctx.Err())
+		}
+		if msg := strings.TrimSpace(stderr.buf.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	if stdout.truncated {
+		return nil, fmt.Errorf("%s: %w of %d bytes", name, ErrOutputLimit, limit)
+	}
+
+	return stdout.buf.Bytes(), nil
+}
+
+// limitedBuffer - буфер вывода, отбрасывающий всё сверх limit. Запись не прерывается ошибкой,
+// иначе команда заблокируется на заполненном канале вывода до истечения срока.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); len(p) > room {
+		b.truncated = true
+		b.buf.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+// commandContext - контекст команды коллектора со сроком timeout секунд (0 - без ограничения).
+func commandContext(ctx context.Context, timeout int) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+}
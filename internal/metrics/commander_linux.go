@@ -0,0 +1,17 @@
+//go:build linux
+
+package metrics
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup - запускает команду в своей группе процессов, чтобы по истечении срока
+// завершить и её потомков (df может породить зависшие на NFS процессы).
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
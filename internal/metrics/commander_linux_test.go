@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRealCommanderRunContext(t *testing.T) {
+	tests := []struct {
+		name      string
+		cmd       RealCommander
+		timeout   time.Duration
+		script    string
+		want      string
+		wantErr   error
+		errSubstr string
+	}{
+		{name: "output", script: "echo ok", want: "ok\n"},
+		{name: "C locale", script: "echo $LC_ALL", want: "C\n"},
+		{name: "stderr in error", script: "echo boom >&2; exit 3", errSubstr: "boom"},
+		{
+			// Потомок держит вывод открытым: без завершения группы процессов команда висела бы 10 секунд
+			name:    "timeout kills process group",
+			timeout: 200 * time.Millisecond,
+			script:  "sleep 10 & sleep 10",
+			wantErr: ErrCommandTimeout,
+		},
+		{
+			name:    "output limit",
+			cmd:     RealCommander{MaxOutput: 1000},
+			script:  "head -c 100000 /dev/zero",
+			wantErr: ErrOutputLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			out, err := tt.cmd.RunContext(ctx, "sh", "-c", tt.script)
+			if elapsed := time.Since(start); elapsed > 3*time.Second {
+				t.Errorf("RunContext() took %v, want to return soon after deadline", elapsed)
+			}
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("RunContext() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.errSubstr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Errorf("RunContext() error = %v, want containing %q", err, tt.errSubstr)
+				}
+			case err != nil:
+				t.Errorf("RunContext() unexpected error: %v", err)
+			case string(out) != tt.want:
+				t.Errorf("RunContext() = %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
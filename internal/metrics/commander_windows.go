@@ -0,0 +1,13 @@
+//go:build windows
+
+package metrics
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup - скрывает окно команды; по истечении срока завершается сам процесс.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+}
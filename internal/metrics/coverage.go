@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"time"
+
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Coverage - покрытие запрошенного периода замерами подсистемы.
+type Coverage struct {
+	Subsystem   string        // Имя подсистемы
+	Samples     int           // Количество замеров в периоде
+	Covered     time.Duration // Фактически покрытая замерами часть периода
+	Expected    int           // Ожидаемое количество замеров в периоде по разрешению подсистемы
+	LastError   string        // Последняя ошибка сбора
+	LastErrorAt time.Time
+}
+
+// Ratio - доля полученных замеров от ожидаемых, не больше 1; без ожидаемых замеров - 0.
+func (c Coverage) Ratio() float64 {
+	if c.Expected <= 0 {
+		return 0
+	}
+	return min(float64(c.Samples)/float64(c.Expected), 1)
+}
+
+// Proto - покрытие для ответа клиенту. Значения устарели (stale), если попытки сбора были,
+// но доля полученных замеров ниже minRatio.
+func (c Coverage) Proto(minRatio float64) *pb.SubsystemCoverage {
+	p := &pb.SubsystemCoverage{
+		Subsystem:      c.Subsystem,
+		Samples:        int32(c.Samples), //nolint:gosec
+		CoveredSeconds: c.Covered.Seconds(),
+		Expected:       int32(c.Expected), //nolint:gosec
+		Ratio:          round(c.Ratio()),
+		LastError:      c.LastError,
+		Stale:          c.Expected > 0 && c.Ratio() < minRatio,
+	}
+	if !c.LastErrorAt.IsZero() {
+		p.LastErrorTime = timestamppb.New(c.LastErrorAt)
+	}
+	return p
+}
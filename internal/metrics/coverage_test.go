@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+)
+
+func TestCoverageProto(t *testing.T) {
+	tests := []struct {
+		name      string
+		cov       Coverage
+		wantRatio float64
+		wantStale bool
+	}{
+		{"full", Coverage{Samples: 10, Expected: 10}, 1, false},
+		{"at threshold", Coverage{Samples: 5, Expected: 10}, 0.5, false},
+		{"partial", Coverage{Samples: 2, Expected: 3}, 0.67, false},
+		{"all failed", Coverage{Samples: 0, Expected: 3, LastError: "sar command failed"}, 0, true},
+		{"no attempts", Coverage{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cov.Proto(0.5)
+			if got.GetRatio() != tt.wantRatio || got.GetStale() != tt.wantStale {
+				t.Errorf("Proto() ratio = %v, stale = %v, want %v, %v", got.GetRatio(), got.GetStale(), tt.wantRatio, tt.wantStale)
+			}
+			if got.GetLastError() != tt.cov.LastError || got.GetLastErrorTime() != nil {
+				t.Errorf("Proto() last error = %q at %v", got.GetLastError(), got.GetLastErrorTime())
+			}
+		})
+	}
+
+	if got := (Coverage{Samples: 1, Expected: 4}).Proto(0.5); !got.GetStale() {
+		t.Errorf("Proto() = %v, want stale below min ratio", got)
+	}
+}
+
+// TestSourceSnapshotFailures - ожидаемое количество замеров считается по времени: неудачные
+// и зависшие попытки сбора снижают покрытие.
+func TestSourceSnapshotFailures(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fail := false
+	src := &source[model.LoadAvgRecord]{
+		name: SubsystemLoadAvg,
+		get: func() (model.LoadAvgRecord, error) {
+			if fail {
+				return model.LoadAvgRecord{}, errors.New("read /proc/loadavg: permission denied")
+			}
+			return model.LoadAvgRecord{Load1min: 1}, nil
+		},
+		aggregate:  averageLoadAvg,
+		resolution: time.Second,
+		retention:  time.Minute,
+		started:    t0,
+	}
+
+	for i := range 4 {
+		fail = i%2 == 1
+		_ = src.collect(t0.Add(time.Duration(i) * time.Second))
+	}
+
+	_, cov := src.snapshot(t0.Add(3*time.Second), 10*time.Second)
+	if cov.Samples != 2 || cov.Expected != 4 || cov.Ratio() != 0.5 {
+		t.Errorf("snapshot() coverage = %+v, want 2 of 4 samples", cov)
+	}
+	if cov.LastError == "" || !cov.LastErrorAt.Equal(t0.Add(3*time.Second)) {
+		t.Errorf("snapshot() last error = %q at %v", cov.LastError, cov.LastErrorAt)
+	}
+
+	// В периоде только неудачная попытка: значений нет, но покрытие и ошибка видны
+	stats, cov := src.snapshot(t0.Add(3*time.Second), time.Second)
+	if stats != nil || cov.Samples != 0 || cov.Expected != 1 || !cov.Proto(0.5).GetStale() {
+		t.Errorf("snapshot() = %v, %+v, want stale coverage without values", stats, cov)
+	}
+
+	// Зависший коллектор не делает попыток, но ожидаемых замеров с каждым интервалом больше
+	_, cov = src.snapshot(t0.Add(7*time.Second), 10*time.Second)
+	if cov.Samples != 2 || cov.Expected != 8 || !cov.Proto(0.5).GetStale() {
+		t.Errorf("snapshot() stuck collector coverage = %+v, want 2 of 8 samples", cov)
+	}
+}
@@ -1,89 +1,45 @@
 package metrics
 
 import (
-	"context"
 	"fmt"
-	"time"
 
-	"github.com/shagrat164/system-monitoring-daemon/internal/config"
-	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
 	"github.com/shagrat164/system-monitoring-daemon/internal/model"
 	pb "github.com/shagrat164/system-monitoring-daemon/proto"
 )
 
-// CollectCPUStats - собирает CPU статистику и отправляет усреднённые данные в канал.
-func CollectCPUStats(ctx context.Context,
-	cfg *config.Config,
-	log *logger.Logger,
-	statsChan chan *pb.StatsResponse,
-	interval, duration int32,
-	cmd Commander,
-) {
-	if !cfg.Enabled.CPU {
-		log.Info("Load CPU collection disabled")
-		return
-	}
+// cpuTimes - накопительные счётчики времени процессора, в единицах платформы.
+type cpuTimes struct {
+	user   uint64
+	system uint64
+	idle   uint64
+	total  uint64 // Всё время, включая iowait, steal и прочее
+}
 
-	n := time.Duration(interval) * time.Second
-	if n <= 0 {
-		n = 5 * time.Second
-	}
-	m := time.Duration(duration) * time.Second
-	if m <= 0 {
-		m = 15 * time.Second
+// cpuUsage - проценты времени процессора между замерами счётчиков prev и cur.
+func cpuUsage(prev, cur cpuTimes) (model.CPUStats, error) {
+	// Сброс счётчиков (или одинаковые замеры) не даёт интервала для расчёта
+	if cur.total <= prev.total || cur.user < prev.user || cur.system < prev.system || cur.idle < prev.idle {
+		return model.CPUStats{}, fmt.Errorf("CPU counters did not advance")
 	}
 
-	maxHistory := int(m / n)     // Максимальное количество записей в истории
-	var history []model.CPUStats //nolint:prealloc
-
-	ticker := time.NewTicker(n)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		cpuStats, err := GetCPUStats(cmd)
-		if err != nil {
-			log.Error(fmt.Sprintf("Failed to collect CPU stats: %v", err))
-			continue
-		}
-
-		history = append(history, cpuStats)
-		if len(history) > maxHistory {
-			history = history[1:] // Обрезаем первую запись
-		}
-		if len(history) == 0 {
-			continue
-		}
-
-		// Условие "молчания": пропускаем отправку, пока не накопим maxHistory записей
-		if len(history) < maxHistory {
-			continue
-		}
-
-		// Теперь вычисляем средние значения и отправляем данные
-		var sumUser, sumSystem, sumIdle float64
-		for _, stat := range history {
-			sumUser += stat.User
-			sumSystem += stat.System
-			sumIdle += stat.Idle
-		}
-		count := float64(len(history))
-		if count == 0 {
-			continue
-		}
-
-		stats := &pb.StatsResponse{
-			CpuUser:   round(sumUser / count),
-			CpuSystem: round(sumSystem / count),
-			CpuIdle:   round(sumIdle / count),
-		}
-
-		// log.Debug(fmt.Sprintf("CPU len(history): %d", len(history)))
+	total := float64(cur.total - prev.total)
+	return model.CPUStats{
+		User:   float64(cur.user-prev.user) / total * 100,
+		System: float64(cur.system-prev.system) / total * 100,
+		Idle:   float64(cur.idle-prev.idle) / total * 100,
+	}, nil
+}
 
-		select {
-		case <-ctx.Done():
-			log.Debug("Gorutine CollectCPUStats is done.")
-			return
-		case statsChan <- stats:
-		}
+// cpuRates - загрузка CPU за период по разности счётчиков крайних замеров: учитывается
+// всё время процессора между ними, а не только моменты замеров.
+func cpuRates(history []cpuTimes) *pb.StatsResponse {
+	usage, err := cpuUsage(history[0], history[len(history)-1])
+	if err != nil {
+		return &pb.StatsResponse{}
+	}
+	return &pb.StatsResponse{
+		CpuUser:   round(usage.User),
+		CpuSystem: round(usage.System),
+		CpuIdle:   round(usage.Idle),
 	}
 }
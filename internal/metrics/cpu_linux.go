@@ -6,41 +6,37 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-
-	"github.com/shagrat164/system-monitoring-daemon/internal/model"
 )
 
-// GetCPUStats - получает CPU статистику с помощью команды sar.
-func GetCPUStats(cmd Commander) (model.CPUStats, error) {
-	output, err := cmd.Run("sar", "-u", "1", "1")
+// readCPUTimes - счётчики времени процессора из первой строки /proc/stat, в тиках:
+// cpu user nice system idle iowait irq softirq steal guest guest_nice.
+// user и system считаются так же, как %user и %system у sar.
+func readCPUTimes(reader FileReader) (cpuTimes, error) {
+	data, err := reader.ReadFile("/proc/stat")
 	if err != nil {
-		return model.CPUStats{}, fmt.Errorf("sar command failed: %w", err)
+		return cpuTimes{}, fmt.Errorf("failed to read /proc/stat: %w", err)
 	}
 
-	lines := strings.Split(strings.ReplaceAll(string(output), ",", "."), "\n")
-	for _, line := range lines {
-		fields := strings.Fields(line)
-		if len(fields) >= 8 && strings.Contains(line, "all") {
-			user, err := strconv.ParseFloat(fields[2], 64) // %user
-			if err != nil {
-				return model.CPUStats{}, fmt.Errorf("failed to parse user: %w", err)
-			}
-			system, err := strconv.ParseFloat(fields[4], 64) // %system
-			if err != nil {
-				return model.CPUStats{}, fmt.Errorf("failed to parse system: %w", err)
-			}
-			idle, err := strconv.ParseFloat(fields[7], 64) // %idle
-			if err != nil {
-				return model.CPUStats{}, fmt.Errorf("failed to parse idle: %w", err)
-			}
-
-			return model.CPUStats{
-				User:   user,
-				System: system,
-				Idle:   idle,
-			}, nil
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 9 || fields[0] != "cpu" {
+		return cpuTimes{}, fmt.Errorf("unexpected /proc/stat cpu line: %q", line)
+	}
+	values := make([]uint64, 8) // user nice system idle iowait irq softirq steal
+	for i := range values {
+		values[i], err = strconv.ParseUint(fields[i+1], 10, 64)
+		if err != nil {
+			return cpuTimes{}, fmt.Errorf("failed to parse /proc/stat field %d: %w", i+1, err)
 		}
 	}
 
-	return model.CPUStats{}, fmt.Errorf("no valid CPU stats found in sar output")
+	times := cpuTimes{
+		user:   values[0],
+		system: values[2] + values[5] + values[6],
+		idle:   values[3],
+	}
+	for _, v := range values {
+		times.total += v
+	}
+	return times, nil
 }
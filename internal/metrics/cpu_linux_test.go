@@ -0,0 +1,39 @@
+package metrics
+
+import "testing"
+
+func TestReadCPUTimes(t *testing.T) {
+	tests := []struct {
+		name    string
+		stat    string
+		want    cpuTimes
+		wantErr bool
+	}{
+		{
+			// system включает irq и softirq, total - все поля, включая nice, iowait и steal
+			name: "aggregate line",
+			stat: "cpu  120 10 60 840 60 5 5 0 0 0\ncpu0 1 2 3 4 5 6 7 0 0 0\n",
+			want: cpuTimes{user: 120, system: 70, idle: 840, total: 1100},
+		},
+		{name: "malformed line", stat: "intr 1 2 3\n", wantErr: true},
+		{name: "bad field", stat: "cpu  x 10 60 840 60 5 5 0 0 0\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readCPUTimes(MockFS{Files: map[string][]byte{"/proc/stat": []byte(tt.stat)}})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("readCPUTimes() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readCPUTimes() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readCPUTimes() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
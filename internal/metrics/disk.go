@@ -1,145 +1,133 @@
 package metrics
 
 import (
-	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/shagrat164/system-monitoring-daemon/internal/config"
-	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
 	"github.com/shagrat164/system-monitoring-daemon/internal/model"
 	pb "github.com/shagrat164/system-monitoring-daemon/proto"
 )
 
-// CollectDiskStats - собирает статистику дисков и отправляет усреднённые данные в канал.
-func CollectDiskStats(ctx context.Context,
-	cfg *config.Config,
-	log *logger.Logger,
-	statsChan chan *pb.StatsResponse,
-	interval, duration int32,
-	cmd Commander,
-) {
-	if !cfg.Enabled.Disk {
-		log.Info("Load disk collection disabled")
-		return
-	}
-
-	n := time.Duration(interval) * time.Second
-	if n <= 0 {
-		n = 5 * time.Second
-	}
-	m := time.Duration(duration) * time.Second
-	if m <= 0 {
-		m = 15 * time.Second
-	}
-
-	maxHistory := int(m / n) // Максимальное количество записей в истории
-	historyMap := make(map[string][]model.DiskStats)
-
-	ticker := time.NewTicker(n)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		diskStats, err := GetDiskStats(cmd)
-		if err != nil {
-			log.Error(fmt.Sprintf("failed to collect disk stats: %v", err))
-			continue
-		}
-
-		for _, stat := range diskStats {
-			h := historyMap[stat.Device]
-			h = append(h, stat)
-			if len(h) > maxHistory {
-				h = h[1:] // Обрезаем первую запись
+// Размер сектора в /proc/diskstats не зависит от устройства.
+const sectorSize = 512
+
+// diskRates - вычисляет точные скорости ввода-вывода по разности счётчиков за период.
+// Устройство, подключённое внутри периода, считается с момента появления и помечается как partial;
+// переполнение и сброс счётчиков учитываются counterDelta.
+func diskRates(history []model.DiskIOStats) []*pb.DiskStats {
+	last := history[len(history)-1]
+
+	var result []*pb.DiskStats
+	for _, disk := range last.Devices {
+		var (
+			prev           *model.DiskIO
+			since          time.Time
+			ios            float64
+			sectorsRead    float64
+			sectorsWritten float64
+		)
+		for _, h := range history {
+			i := slices.IndexFunc(h.Devices, func(d model.DiskIO) bool { return d.Device == disk.Device })
+			if i < 0 {
+				prev = nil // Устройство отключалось - считаем заново с момента подключения
+				continue
 			}
-			historyMap[stat.Device] = h
-		}
-
-		// Формируем усреднённые данные
-		var pbDiskStats []*pb.DiskStats
-		allDevicesReady := true
-		for device, h := range historyMap {
-			// Проверяем, накоплено ли достаточно записей для каждого устройства
-			if len(h) < maxHistory {
-				allDevicesReady = false
-				break
-			}
-
-			var sumTps, sumKBs float64
-			for _, stat := range h {
-				sumTps += stat.Tps
-				sumKBs += stat.KBs
+			cur := &h.Devices[i]
+			if prev == nil {
+				since = h.Time
+			} else {
+				ios += counterDelta(prev.Reads, cur.Reads) + counterDelta(prev.Writes, cur.Writes)
+				sectorsRead += counterDelta(prev.SectorsRead, cur.SectorsRead)
+				sectorsWritten += counterDelta(prev.SectorsWritten, cur.SectorsWritten)
 			}
-			count := float64(len(h))
-			pbDiskStats = append(pbDiskStats, &pb.DiskStats{
-				Device:  device,
-				Tps:     round(sumTps / count),
-				KbTotal: round(sumKBs / count),
-			})
+			prev = cur
 		}
 
-		// Условие "молчания": отправляем данные только если все устройства имеют полную историю
-		if !allDevicesReady || len(pbDiskStats) == 0 {
-			continue
-		}
-
-		stats := &pb.StatsResponse{
-			DiskStats: pbDiskStats,
+		elapsed := last.Time.Sub(since).Seconds()
+		if elapsed <= 0 {
+			continue // Один замер - скорость ещё неизвестна
 		}
+		kbRead := sectorsRead * sectorSize / 1024 / elapsed
+		kbWrite := sectorsWritten * sectorSize / 1024 / elapsed
+		result = append(result, &pb.DiskStats{
+			Device:  disk.Device,
+			Tps:     round(ios / elapsed),
+			KbRead:  round(kbRead),
+			KbWrite: round(kbWrite),
+			KbTotal: round(kbRead + kbWrite),
+			Partial: since.After(history[0].Time),
+		})
+	}
 
-		// log.Debug(fmt.Sprintf("Disk len(historyMap): %d", len(historyMap)))
+	return result
+}
 
-		select {
-		case <-ctx.Done():
-			log.Debug("Gorutine CollectDiskStats is done.")
-			return
-		case statsChan <- stats:
-		}
+// Граница 32-битного счётчика: на 32-битных ядрах счётчики /proc/diskstats - unsigned long.
+const counterWrap32 = 1 << 32
+
+// counterDelta - прирост счётчика между замерами prev и cur. Уменьшение значения, близкого
+// к 2^32, считается переполнением 32-битного счётчика, любое другое - сбросом при
+// переподключении устройства: новые счётчики идут от нуля, и прирост равен cur.
+func counterDelta(prev, cur float64) float64 {
+	switch {
+	case cur >= prev:
+		return cur - prev
+	case prev < counterWrap32 && prev >= counterWrap32*3/4:
+		return counterWrap32 - prev + cur
+	default:
+		return cur
 	}
 }
 
-// GetDiskStats - получает статистику дисков с помощью команды iostat.
-func GetDiskStats(cmd Commander) ([]model.DiskStats, error) {
-	output, err := cmd.Run("iostat", "-d", "-k", "1", "1")
+// GetDiskIOStats - читает накопительные счётчики дисков из /proc/diskstats.
+// Разделы и устройства без единой операции ввода-вывода пропускаются.
+func GetDiskIOStats(reader FSReader) (model.DiskIOStats, error) {
+	data, err := reader.ReadFile("/proc/diskstats")
 	if err != nil {
-		return nil, fmt.Errorf("iostat command failed: %w", err)
+		return model.DiskIOStats{}, fmt.Errorf("failed to read /proc/diskstats: %w", err)
 	}
 
-	lines := strings.Split(strings.ReplaceAll(string(output), ",", "."), "\n")
-	var stats []model.DiskStats
-	for i, line := range lines {
-		if i < 3 {
-			continue // Пропускаем первые три строки (системная информация и заголовок)
-		}
+	// В /sys/block есть только целые устройства; без него оставляем все строки
+	disks, err := reader.ReadDir("/sys/block")
+	if err != nil {
+		disks = nil
+	}
 
+	stats := model.DiskIOStats{Time: time.Now()}
+	for _, line := range strings.Split(string(data), "\n") {
 		fields := strings.Fields(line)
-		// Проверяем строку с данными (начинается с "Device" — заголовок, пропускаем)
-		if len(fields) >= 6 && fields[0] != "" {
-			tps, err := strconv.ParseFloat(fields[1], 64) // tps
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse tps: %w", err)
-			}
-			kbRead, err := strconv.ParseFloat(fields[2], 64) // kB_read/s
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse kB_read/s: %w", err)
-			}
-			kbWrite, err := strconv.ParseFloat(fields[3], 64) // kB_wrtn/s
+		if len(fields) < 10 {
+			continue
+		}
+		if disks != nil && !slices.Contains(disks, fields[2]) {
+			continue
+		}
+
+		var counters [4]float64
+		for i, field := range []int{3, 5, 7, 9} { // Чтения, секторы чтения, записи, секторы записи
+			counters[i], err = strconv.ParseFloat(fields[field], 64)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse kB_wrtn/s: %w", err)
+				return model.DiskIOStats{}, fmt.Errorf("failed to parse %s counters: %w", fields[2], err)
 			}
-
-			stats = append(stats, model.DiskStats{
-				Device: fields[0],
-				Tps:    tps,
-				KBs:    kbRead + kbWrite,
-			})
 		}
+		if counters == [4]float64{} {
+			continue
+		}
+
+		stats.Devices = append(stats.Devices, model.DiskIO{
+			Device:         fields[2],
+			Reads:          counters[0],
+			SectorsRead:    counters[1],
+			Writes:         counters[2],
+			SectorsWritten: counters[3],
+		})
 	}
 
-	if len(stats) == 0 {
-		return nil, fmt.Errorf("no valid disk stats found in iostat output")
+	if len(stats.Devices) == 0 {
+		return model.DiskIOStats{}, fmt.Errorf("no valid disk stats found in /proc/diskstats")
 	}
 
 	return stats, nil
@@ -1,156 +1,135 @@
 package metrics
 
 import (
-	"math"
 	"strings"
 	"testing"
 	"time"
 
-	"github.com/shagrat164/system-monitoring-daemon/internal/config"
-	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
 	"github.com/shagrat164/system-monitoring-daemon/internal/model"
 	pb "github.com/shagrat164/system-monitoring-daemon/proto"
 )
 
-func TestGetDiskStats(t *testing.T) {
+const diskstats = `   8       0 sda 1000 10 80000 500 2000 20 40000 900 0 1200 1400 0 0 0 0
+   8       1 sda1 900 10 70000 400 1900 20 38000 800 0 1100 1200 0 0 0 0
+   7       0 loop0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0
+ 259       0 nvme0n1 10 0 160 1 5 0 80 2 0 3 3 0 0 0 0
+`
+
+func TestGetDiskIOStats(t *testing.T) {
 	tests := []struct {
 		name        string
-		cmd         Commander
-		wantStats   []model.DiskStats
-		wantErr     bool
+		fs          MockFS
+		wantDevices []model.DiskIO
 		errContains string
 	}{
 		{
-			name: "valid data",
-			cmd: &MockCommander{
-				Outputs: [][]byte{
-					[]byte(`Linux 5.4.0-42-generic (host)  02/23/2025  _x86_64_  (4 CPU)
-					
-					Device            tps    kB_read/s    kB_wrtn/s    kB_read    kB_wrtn
-					sda             10.00       40.00       20.00      40000      20000`),
-				},
+			name: "whole devices only",
+			fs: MockFS{
+				Files: map[string][]byte{"/proc/diskstats": []byte(diskstats)},
+				Dirs:  map[string][]string{"/sys/block": {"sda", "loop0", "nvme0n1"}},
 			},
-			wantStats: []model.DiskStats{
-				{Device: "sda", Tps: 10.00, KBs: 60.00},
+			wantDevices: []model.DiskIO{
+				{Device: "sda", Reads: 1000, Writes: 2000, SectorsRead: 80000, SectorsWritten: 40000},
+				{Device: "nvme0n1", Reads: 10, Writes: 5, SectorsRead: 160, SectorsWritten: 80},
 			},
-			wantErr: false,
+		},
+		{
+			name:        "without sysfs",
+			fs:          MockFS{Files: map[string][]byte{"/proc/diskstats": []byte(diskstats)}},
+			wantDevices: make([]model.DiskIO, 3), // sda, sda1, nvme0n1
+		},
+		{
+			name:        "no diskstats",
+			fs:          MockFS{},
+			errContains: "failed to read /proc/diskstats",
+		},
+		{
+			name: "bad counter",
+			fs: MockFS{Files: map[string][]byte{
+				"/proc/diskstats": []byte("   8 0 sda x 0 0 0 0 0 0 0 0 0 0\n"),
+			}},
+			errContains: "failed to parse sda counters",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			stats, err := GetDiskStats(tt.cmd)
-			if tt.wantErr {
-				if err == nil {
-					t.Errorf("GetDiskStats() error = nil, want error containing %q", tt.errContains)
-				} else if !strings.Contains(err.Error(), tt.errContains) {
-					t.Errorf("GetDiskStats() error = %v, want error containing %q", err, tt.errContains)
+			stats, err := GetDiskIOStats(tt.fs)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("GetDiskIOStats() error = %v, want error containing %q", err, tt.errContains)
 				}
 				return
 			}
-
 			if err != nil {
-				t.Errorf("GetDiskStats() unexpected error: %v", err)
-				return
+				t.Fatalf("GetDiskIOStats() unexpected error: %v", err)
 			}
-
-			if len(stats) != len(tt.wantStats) {
-				t.Errorf("GetDiskStats() got %d stats, want %d", len(stats), len(tt.wantStats))
+			if len(stats.Devices) != len(tt.wantDevices) {
+				t.Fatalf("GetDiskIOStats() got %d devices, want %d", len(stats.Devices), len(tt.wantDevices))
 			}
-			for i, got := range stats {
-				want := tt.wantStats[i]
-				if got.Device != want.Device || math.Abs(got.Tps-want.Tps) > 0.01 || math.Abs(got.KBs-want.KBs) > 0.01 {
-					t.Errorf("GetDiskStats() got = %+v, want %+v", got, want)
+			for i, want := range tt.wantDevices {
+				if want.Device != "" && stats.Devices[i] != want {
+					t.Errorf("GetDiskIOStats() device #%d = %+v, want %+v", i, stats.Devices[i], want)
 				}
 			}
 		})
 	}
 }
 
-func TestCollectDiskStats(t *testing.T) {
+func TestDiskRates(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sample := func(sec int, devices ...model.DiskIO) model.DiskIOStats {
+		return model.DiskIOStats{Time: start.Add(time.Duration(sec) * time.Second), Devices: devices}
+	}
+	sda := func(reads, sectors float64) model.DiskIO {
+		return model.DiskIO{Device: "sda", Reads: reads, Writes: reads, SectorsRead: sectors, SectorsWritten: sectors}
+	}
+
 	tests := []struct {
-		name      string
-		interval  int32
-		duration  int32
-		cmd       Commander
-		wantCount int
-		wantStats []model.DiskStats
+		name    string
+		history []model.DiskIOStats
+		want    []*pb.DiskStats
 	}{
 		{
-			name:     "basic averaging with silence",
-			interval: 1,
-			duration: 3,
-			cmd: &MockCommander{
-				Outputs: [][]byte{
-					[]byte(`Linux 5.4.0-42-generic (host)  02/23/2025  _x86_64_  (4 CPU)
-					
-					Device            tps    kB_read/s    kB_wrtn/s    kB_read    kB_wrtn
-					sda             10.00       40.00       20.00      40000      20000`),
-					[]byte(`Linux 5.4.0-42-generic (host)  02/23/2025  _x86_64_  (4 CPU)
-					
-					Device            tps    kB_read/s    kB_wrtn/s    kB_read    kB_wrtn
-					sda             20.00       80.00       40.00      80000      40000`),
-					[]byte(`Linux 5.4.0-42-generic (host)  02/23/2025  _x86_64_  (4 CPU)
-					
-					Device            tps    kB_read/s    kB_wrtn/s    kB_read    kB_wrtn
-					sda             30.00      120.00       60.00     120000      60000`),
-					[]byte(`Linux 5.4.0-42-generic (host)  02/23/2025  _x86_64_  (4 CPU)
-					
-					Device            tps    kB_read/s    kB_wrtn/s    kB_read    kB_wrtn
-					sda             40.00      160.00       80.00     160000      80000`),
-				},
-			},
-			wantCount: 2, // Отправка начинается с t=2 (3-я итерация), всего 2 отправки за 4 итерации
-			wantStats: []model.DiskStats{
-				{Device: "sda", Tps: 20.00, KBs: 120.00}, // t=2: среднее за t=0, t=1, t=2
-				{Device: "sda", Tps: 30.00, KBs: 180.00}, // t=3: среднее за t=1, t=2, t=3
-			},
+			// I/O между замерами учитывается целиком: 300 операций и 6000 секторов за 10 секунд
+			name:    "exact rate by delta",
+			history: []model.DiskIOStats{sample(0, sda(100, 2000)), sample(5, sda(150, 2500)), sample(10, sda(250, 4000))},
+			want:    []*pb.DiskStats{{Device: "sda", Tps: 30, KbRead: 100, KbWrite: 100, KbTotal: 200}},
+		},
+		{
+			name:    "32-bit wrap",
+			history: []model.DiskIOStats{sample(0, sda(counterWrap32-50, counterWrap32-1024)), sample(10, sda(50, 1024))},
+			want:    []*pb.DiskStats{{Device: "sda", Tps: 20, KbRead: 102.4, KbWrite: 102.4, KbTotal: 204.8}},
+		},
+		{
+			// После переподключения счётчики идут от нуля: прирост равен новому значению
+			name:    "reset after re-attach",
+			history: []model.DiskIOStats{sample(0, sda(5000, 90000)), sample(10, sda(100, 2000))},
+			want:    []*pb.DiskStats{{Device: "sda", Tps: 20, KbRead: 100, KbWrite: 100, KbTotal: 200}},
+		},
+		{
+			name:    "attached inside window",
+			history: []model.DiskIOStats{sample(0, sda(0, 0)), sample(5), sample(8, sda(10, 100)), sample(10, sda(30, 500))},
+			want:    []*pb.DiskStats{{Device: "sda", Tps: 20, KbRead: 100, KbWrite: 100, KbTotal: 200, Partial: true}},
+		},
+		{
+			name:    "single sample",
+			history: []model.DiskIOStats{sample(0), sample(10, sda(30, 500))},
+			want:    nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cfg := config.NewConfig()
-			cfg.Enabled.Disk = true
-			log, _ := logger.New(cfg.Logger)
-			statsChan := make(chan *pb.StatsResponse, tt.wantCount+1)
-
-			go CollectDiskStats(t.Context(), cfg, log, statsChan, tt.interval, tt.duration, tt.cmd)
-
-			type diskStats struct {
-				Device string
-				Tps    float64
-				KBs    float64
-			}
-			var gotStats []diskStats
-			timeout := time.After(time.Duration((int(tt.duration)+tt.wantCount)*2) * time.Second) // Увеличенный таймаут
-			for i := 0; i < tt.wantCount; i++ {
-				select {
-				case stats := <-statsChan:
-					for _, d := range stats.DiskStats {
-						gotStats = append(gotStats, diskStats{
-							Device: d.Device,
-							Tps:    d.Tps,
-							KBs:    d.KbTotal,
-						})
-						t.Logf("Iteration %d: got %+v", i, d)
-					}
-				case <-timeout:
-					t.Fatalf("Timed out waiting for stats after %d iterations, got %d stats", tt.wantCount, len(gotStats))
-				}
+			got := diskRates(tt.history)
+			if len(got) != len(tt.want) {
+				t.Fatalf("diskRates() got %d devices, want %d", len(got), len(tt.want))
 			}
-
-			if len(gotStats) != tt.wantCount {
-				t.Errorf("CollectDiskStats sent %d stats, want %d", len(gotStats), tt.wantCount)
-			}
-
-			const epsilon = 0.01
-			for i, got := range gotStats {
-				want := tt.wantStats[i]
-				if got.Device != want.Device ||
-					math.Abs(got.Tps-want.Tps) > epsilon ||
-					math.Abs(got.KBs-want.KBs) > epsilon {
-					t.Errorf("CollectDiskStats stats #%d = %+v, want %+v", i, got, want)
+			for i, want := range tt.want {
+				g := got[i]
+				if g.GetDevice() != want.GetDevice() || g.GetTps() != want.GetTps() || g.GetKbRead() != want.GetKbRead() ||
+					g.GetKbWrite() != want.GetKbWrite() || g.GetKbTotal() != want.GetKbTotal() || g.GetPartial() != want.GetPartial() {
+					t.Errorf("diskRates() #%d = %v, want %v", i, g, want)
 				}
 			}
 		})
@@ -0,0 +1,563 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/history"
+	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Имена подсистем (совпадают с ключами секции [metrics] конфигурации).
+const (
+	SubsystemLoadAvg      = "load_avg"
+	SubsystemCPU          = "cpu"
+	SubsystemDisk         = "disk"
+	SubsystemFilesystem   = "filesystem"
+	SubsystemFD           = "fd"
+	SubsystemNetProto     = "net_proto"
+	SubsystemNetIface     = "net_iface"
+	SubsystemRAID         = "raid"
+	SubsystemBlockDevices = "block_devices"
+	SubsystemDaemon       = "daemon"
+)
+
+// Engine - общий для всех клиентов движок сбора. Каждая включённая подсистема опрашивается
+// со своим разрешением (не чаще resolution), замеры хранятся retention и переиспользуются запросами.
+type Engine struct {
+	log        *logger.Logger
+	started    time.Time
+	resolution time.Duration // Наименьший период опроса подсистем (0 - без ограничения)
+	retention  time.Duration
+	sources    []engineSource
+	history    *history.Store
+
+	ewmaMu sync.Mutex
+	ewma   map[time.Duration]*ewma // Состояния EWMA по запрошенным периодам полураспада
+
+	entityMu sync.Mutex
+	trackers map[string]*entityTracker // Сущности подсистем с метками (диски, точки монтирования...)
+	events   []*pb.EntityEvent         // События за retention в порядке времени
+
+	supervisor *Supervisor // Перезапуск источников после паники, общий с потоками клиентов
+	self       *SelfStats  // Внутренние метрики демона, общие с потоками клиентов
+
+	base config.ResolutionConfig // Базовое разрешение подсистем: источник опрашивается не чаще
+}
+
+// engineSource - подсистема движка со своей историей замеров.
+type engineSource interface {
+	subsystem() string
+	period() time.Duration
+	collect(now time.Time) error
+	snapshot(now time.Time, window time.Duration) (*pb.StatsResponse, Coverage)
+	series(now time.Time, window time.Duration) map[history.Key][]float64
+	latest() *pb.StatsResponse
+}
+
+// timed - замер с временем получения.
+type timed[T any] struct {
+	at    time.Time
+	value T
+}
+
+// source - история замеров одной подсистемы.
+type source[T any] struct {
+	name       string
+	get        func() (T, error)                  // Получение одного замера
+	aggregate  func(window []T) *pb.StatsResponse // Свёртка окна замеров в ответ
+	counter    bool                               // Скорости по счётчикам: нужен опорный замер перед окном
+	resolution time.Duration
+	retention  time.Duration
+	started    time.Time // Запуск движка: раньше замеров не ожидается
+
+	mu        sync.Mutex
+	samples   []timed[T]
+	lastErr   string // Последняя ошибка сбора
+	lastErrAt time.Time
+}
+
+// NewEngine - создаёт движок для подсистем, включённых в конфигурации.
+func NewEngine(cfg *config.Config, log *logger.Logger, reader FSReader, cmd Commander) *Engine {
+	// Без общего периода подсистемы опрашиваются со своим базовым разрешением, в том числе чаще секунды
+	resolution := max(time.Duration(cfg.Engine.Resolution)*time.Second, 0)
+	retention := max(time.Duration(cfg.Engine.Retention)*time.Second, resolution, time.Second)
+
+	e := &Engine{
+		log:        log,
+		started:    time.Now(),
+		resolution: resolution,
+		retention:  retention,
+		history:    newHistoryStore(cfg.History),
+		trackers:   make(map[string]*entityTracker),
+		supervisor: NewSupervisor(cfg.Supervisor, log),
+		self:       NewSelfStats(reader),
+		base:       cfg.Resolution,
+	}
+	expire := time.Duration(cfg.Lifecycle.Expire) * time.Second
+	for _, name := range Subsystems {
+		// Метки внутренних метрик (коллекторы, методы) - не сущности хоста
+		if name != SubsystemDaemon {
+			e.trackers[name] = newEntityTracker(name, expire)
+		}
+	}
+	// Длительность команд учитывается во внутренних метриках
+	cmd = e.self.Commander(cmd)
+
+	addSource(e, cfg.Enabled.LoadAvg, &source[model.LoadAvgRecord]{
+		name: SubsystemLoadAvg,
+		get: func() (model.LoadAvgRecord, error) {
+			load1, load5, load15, err := GetLoadAvg(reader)
+			return model.LoadAvgRecord{Load1min: load1, Load5min: load5, Load15min: load15}, err
+		},
+		aggregate: averageLoadAvg,
+	})
+	// Загрузка CPU - по счётчикам /proc/stat (Windows - GetSystemTimes) без запуска sar
+	addSource(e, cfg.Enabled.CPU, &source[cpuTimes]{
+		name:      SubsystemCPU,
+		get:       func() (cpuTimes, error) { return readCPUTimes(reader) },
+		aggregate: cpuRates,
+		counter:   true,
+	})
+	addSource(e, cfg.Enabled.Disk, &source[model.DiskIOStats]{
+		name: SubsystemDisk,
+		get:  func() (model.DiskIOStats, error) { return GetDiskIOStats(reader) },
+		aggregate: func(window []model.DiskIOStats) *pb.StatsResponse {
+			return &pb.StatsResponse{DiskStats: diskRates(window)}
+		},
+		counter: true,
+	})
+	addSource(e, cfg.Enabled.Filesystem, &source[[]model.FilesystemStats]{
+		name: SubsystemFilesystem,
+		get: func() ([]model.FilesystemStats, error) {
+			ctx, cancel := commandContext(context.Background(), cfg.Commands.FilesystemTimeout)
+			defer cancel()
+			return GetFilesystemStats(ctx, cmd)
+		},
+		aggregate: func(window [][]model.FilesystemStats) *pb.StatsResponse {
+			return &pb.StatsResponse{FilesystemStats: averageFilesystemStats(groupFilesystemStats(window), len(window))}
+		},
+	})
+	addSource(e, cfg.Enabled.FD, &source[model.FDStats]{
+		name: SubsystemFD,
+		get:  func() (model.FDStats, error) { return GetFDStats(reader) },
+		aggregate: func(window []model.FDStats) *pb.StatsResponse {
+			return &pb.StatsResponse{FdStats: averageFDStats(window, cfg.FD)}
+		},
+	})
+	addSource(e, cfg.Enabled.NetProto, &source[model.NetProtoStats]{
+		name: SubsystemNetProto,
+		get:  func() (model.NetProtoStats, error) { return GetNetProtoStats(reader) },
+		aggregate: func(window []model.NetProtoStats) *pb.StatsResponse {
+			return &pb.StatsResponse{NetProtoStats: netProtoRates(window)}
+		},
+		counter: true,
+	})
+	addSource(e, cfg.Enabled.NetIface, &source[model.NetIfaceStats]{
+		name: SubsystemNetIface,
+		get:  func() (model.NetIfaceStats, error) { return GetNetIfaceStats(reader) },
+		aggregate: func(window []model.NetIfaceStats) *pb.StatsResponse {
+			return &pb.StatsResponse{NetIfaceStats: netIfaceRates(window)}
+		},
+		counter: true,
+	})
+	addSource(e, cfg.Enabled.RAID, &source[model.RAIDStats]{
+		name: SubsystemRAID,
+		get:  func() (model.RAIDStats, error) { return GetRAIDStats(reader) },
+		aggregate: func(window []model.RAIDStats) *pb.StatsResponse {
+			return &pb.StatsResponse{RaidArrays: raidArrays(window)}
+		},
+	})
+	addSource(e, cfg.Enabled.BlockDevices, &source[[]model.BlockDevice]{
+		name: SubsystemBlockDevices,
+		get:  func() ([]model.BlockDevice, error) { return GetBlockDevices(reader) },
+		aggregate: func(window [][]model.BlockDevice) *pb.StatsResponse {
+			return &pb.StatsResponse{BlockDevices: blockDevicesToPb(window[len(window)-1])}
+		},
+	})
+	addSource(e, cfg.Enabled.Daemon, &source[daemonSample]{
+		name: SubsystemDaemon,
+		get:  func() (daemonSample, error) { return e.self.sample(time.Now()) },
+		aggregate: func(window []daemonSample) *pb.StatsResponse {
+			return &pb.StatsResponse{DaemonStats: daemonStats(window)}
+		},
+		counter: true,
+	})
+
+	return e
+}
+
+// newHistoryStore - создаёт хранилище истории по настройкам уровней.
+func newHistoryStore(cfg config.HistoryConfig) *history.Store {
+	tiers := make([]history.Tier, 0, len(cfg.Tiers))
+	for _, t := range cfg.Tiers {
+		tiers = append(tiers, history.Tier{
+			Resolution: time.Duration(t.Resolution) * time.Second,
+			Retention:  time.Duration(t.Retention) * time.Second,
+		})
+	}
+	return history.NewStore(tiers, cfg.MemoryBudgetMB<<20)
+}
+
+// addSource - подключает подсистему к движку, если она включена.
+func addSource[T any](e *Engine, enabled bool, src *source[T]) {
+	if !enabled {
+		return
+	}
+	src.resolution = e.Resolution(src.name)
+	src.retention = max(e.retention, src.resolution)
+	src.started = e.started
+	e.sources = append(e.sources, src)
+}
+
+// Run - запускает опрос подсистем, каждая в своей горутине, до отмены контекста.
+func (e *Engine) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, src := range e.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.supervisor.Run(ctx, src.subsystem(), func(ctx context.Context) {
+				e.runSource(ctx, src)
+			})
+		}()
+	}
+	wg.Wait()
+}
+
+// runSource - опрашивает подсистему сразу при старте и далее раз в её resolution.
+func (e *Engine) runSource(ctx context.Context, src engineSource) {
+	ticker := time.NewTicker(src.period())
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		err := src.collect(now)
+		e.self.ObserveCollector(src.subsystem(), time.Since(now), err)
+		if err != nil {
+			e.log.Error(fmt.Sprintf("Failed to collect %s: %v", src.subsystem(), err))
+		} else if stats := src.latest(); stats != nil {
+			values := FlattenStats(src.subsystem(), stats)
+			e.history.Append(now, values)
+			e.updateEWMA(src.subsystem(), now, values)
+			e.observeEntities(src.subsystem(), now, values)
+		}
+
+		select {
+		case <-ctx.Done():
+			e.log.Debug(fmt.Sprintf("Engine source %s is done.", src.subsystem()))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Supervisor - супервизор коллекторов движка: по нему в ответы попадают перезапуски подсистем.
+func (e *Engine) Supervisor() *Supervisor {
+	return e.supervisor
+}
+
+// Self - внутренние метрики демона: замеры коллекторов движка, потоки клиентов и их очереди.
+func (e *Engine) Self() *SelfStats {
+	return e.self
+}
+
+// Resolution - период опроса подсистемы движком: её базовое разрешение, но не чаще общего периода.
+func (e *Engine) Resolution(subsystem string) time.Duration {
+	if resolution := max(e.resolution, BaseResolution(e.base, subsystem)); resolution > 0 {
+		return resolution
+	}
+	return time.Second // Ни общий период, ни разрешение подсистемы не заданы
+}
+
+// Retention - сколько движок хранит замеры: окна запросов длиннее не покрываются.
+func (e *Engine) Retention() time.Duration {
+	return e.retention
+}
+
+// Started - время запуска движка: с него копятся окна запросов.
+func (e *Engine) Started() time.Time {
+	return e.started
+}
+
+// History - хранилище истории значений, накопленной движком.
+func (e *Engine) History() *history.Store {
+	return e.history
+}
+
+// Snapshot - агрегирует накопленные замеры за период [now-window, now] по выбранным подсистемам.
+// Возвращает то, что есть, вместе с покрытием периода по каждой подсистеме.
+func (e *Engine) Snapshot(now time.Time, window time.Duration, selected config.MetricsConfig) (*pb.StatsResponse, []Coverage) {
+	return e.merge(selected, func(src engineSource) (*pb.StatsResponse, Coverage) {
+		return src.snapshot(now, window)
+	})
+}
+
+// EWMASnapshot - сглаженные значения выбранных подсистем с периодом полураспада halfLife.
+// Состояние для нового периода заводится при первом запросе по последнему замеру, поэтому
+// ответ есть сразу; покрытие считается за период [now-halfLife, now].
+func (e *Engine) EWMASnapshot(now time.Time,
+	halfLife time.Duration,
+	selected config.MetricsConfig,
+) (*pb.StatsResponse, []Coverage) {
+	e.ewmaMu.Lock()
+	defer e.ewmaMu.Unlock()
+
+	w, ok := e.ewma[halfLife]
+	if !ok {
+		if e.ewma == nil {
+			e.ewma = make(map[time.Duration]*ewma)
+		}
+		w = newEWMA(halfLife, now)
+		for _, src := range e.sources {
+			if stats := src.latest(); stats != nil {
+				w.update(src.subsystem(), now, FlattenStats(src.subsystem(), stats))
+			}
+		}
+		e.ewma[halfLife] = w
+	}
+	w.lastUsed = now
+
+	return e.merge(selected, func(src engineSource) (*pb.StatsResponse, Coverage) {
+		_, cov := src.snapshot(now, halfLife)
+		part := src.latest()
+		if part != nil {
+			walkStats(src.subsystem(), part, w.get)
+		}
+		return part, cov
+	})
+}
+
+// updateEWMA - учитывает замер подсистемы во всех состояниях EWMA. Периоды полураспада,
+// которые клиенты не запрашивали дольше retention, забываются.
+func (e *Engine) updateEWMA(subsystem string, at time.Time, values map[history.Key]float64) {
+	e.ewmaMu.Lock()
+	defer e.ewmaMu.Unlock()
+
+	for halfLife, w := range e.ewma {
+		if at.Sub(w.lastUsed) > e.retention {
+			delete(e.ewma, halfLife)
+			continue
+		}
+		w.update(subsystem, at, values)
+	}
+}
+
+// observeEntities - учитывает сущности замера подсистемы и сохраняет события появления и удаления.
+func (e *Engine) observeEntities(subsystem string, at time.Time, values map[history.Key]float64) {
+	e.entityMu.Lock()
+	defer e.entityMu.Unlock()
+
+	tracker, ok := e.trackers[subsystem]
+	if !ok {
+		return
+	}
+	e.events = append(e.events, tracker.observe(at, entityNames(values))...)
+
+	keep := at.Add(-e.retention)
+	cut := sort.Search(len(e.events), func(i int) bool { return !e.events[i].GetTime().AsTime().Before(keep) })
+	e.events = e.events[cut:]
+}
+
+// Events - события появления и удаления сущностей выбранных подсистем за период (from, to].
+func (e *Engine) Events(from, to time.Time, selected config.MetricsConfig) []*pb.EntityEvent {
+	e.entityMu.Lock()
+	defer e.entityMu.Unlock()
+
+	var result []*pb.EntityEvent
+	for _, event := range e.events {
+		at := event.GetTime().AsTime()
+		if !at.After(from) || at.After(to) {
+			continue
+		}
+		if flag := subsystemFlag(&selected, event.GetSubsystem()); flag != nil && *flag {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// merge - объединяет ответы выбранных подсистем, полученные get, в один ответ с покрытием.
+func (e *Engine) merge(selected config.MetricsConfig,
+	get func(src engineSource) (*pb.StatsResponse, Coverage),
+) (*pb.StatsResponse, []Coverage) {
+	stats := &pb.StatsResponse{}
+	coverage := make([]Coverage, 0, len(e.sources))
+	for _, src := range e.sources {
+		if flag := subsystemFlag(&selected, src.subsystem()); flag == nil || !*flag {
+			continue
+		}
+		part, cov := get(src)
+		if part != nil {
+			proto.Merge(stats, part)
+		}
+		coverage = append(coverage, cov)
+	}
+
+	if len(stats.GetBlockDevices()) > 0 {
+		JoinBlockDevices(stats)
+	}
+
+	return stats, coverage
+}
+
+// Aggregates - считает запрошенные агрегаты выбранных подсистем по замерам за период [now-window, now].
+// Подсистемы, которые движок не собирает, пропускаются.
+func (e *Engine) Aggregates(now time.Time,
+	window time.Duration,
+	selected config.MetricsConfig,
+	requests []*pb.AggregationRequest,
+) []*pb.Aggregate {
+	var result []*pb.Aggregate
+	for _, req := range requests {
+		if flag := subsystemFlag(&selected, req.GetSubsystem()); flag == nil || !*flag {
+			continue
+		}
+		for _, src := range e.sources {
+			if src.subsystem() == req.GetSubsystem() {
+				result = append(result, aggregateSeries(src.series(now, window), req.GetFunctions())...)
+			}
+		}
+	}
+	return result
+}
+
+func (s *source[T]) subsystem() string {
+	return s.name
+}
+
+// period - период опроса подсистемы.
+func (s *source[T]) period() time.Duration {
+	return s.resolution
+}
+
+// collect - делает замер и добавляет его в историю, отбрасывая замеры старше retention.
+func (s *source[T]) collect(now time.Time) error {
+	value, err := s.get()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keep := now.Add(-s.retention)
+	if err != nil {
+		s.lastErr = err.Error()
+		s.lastErrAt = now
+		return err
+	}
+
+	s.samples = append(s.samples, timed[T]{at: now, value: value})
+
+	// Для счётчиков сохраняем один замер сверх retention в качестве опорного
+	cut := sort.Search(len(s.samples), func(i int) bool { return !s.samples[i].at.Before(keep) })
+	if s.counter && cut > 0 {
+		cut--
+	}
+	if cut > 0 {
+		n := copy(s.samples, s.samples[cut:])
+		clear(s.samples[n:])
+		s.samples = s.samples[:n]
+	}
+
+	return nil
+}
+
+// latest - значения по последнему замеру (для счётчиков - по двум последним).
+func (s *source[T]) latest() *pb.StatsResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	need := 1
+	if s.counter {
+		need = 2
+	}
+	if len(s.samples) < need {
+		return nil
+	}
+
+	values := make([]T, 0, need)
+	for _, sample := range s.samples[len(s.samples)-need:] {
+		values = append(values, sample.value)
+	}
+	return s.aggregate(values)
+}
+
+// snapshot - сворачивает замеры из периода (now-window, now].
+func (s *source[T]) snapshot(now time.Time, window time.Duration) (*pb.StatsResponse, Coverage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cov := Coverage{Subsystem: s.name, LastError: s.lastErr, LastErrorAt: s.lastErrAt}
+
+	// Ожидается по замеру на каждый интервал resolution периода, но не раньше запуска движка:
+	// неудачные и зависшие попытки сбора замеров не дают и снижают покрытие
+	elapsed := min(window, max(now.Sub(s.started), 0))
+	cov.Expected = int(elapsed / s.resolution)
+	// Первый замер делается сразу при запуске; у счётчика он только опорный
+	if elapsed < window && !s.counter {
+		cov.Expected++
+	}
+	samples := s.window(now, window)
+
+	if len(samples) == 0 || (s.counter && len(samples) < 2) {
+		return nil, cov
+	}
+
+	values := make([]T, 0, len(samples))
+	for _, sample := range samples {
+		values = append(values, sample.value)
+	}
+
+	// Замер характеризует предшествующий ему интервал resolution; для счётчиков - разность крайних замеров
+	span := samples[len(samples)-1].at.Sub(samples[0].at)
+	cov.Samples = len(samples)
+	if s.counter {
+		cov.Samples--
+	} else {
+		span += s.resolution
+	}
+	cov.Covered = min(span, window)
+
+	return s.aggregate(values), cov
+}
+
+// series - раскладывает каждый замер периода (now-window, now] на числовые ряды;
+// для счётчиков значение замера - скорость относительно предыдущего.
+func (s *source[T]) series(now time.Time, window time.Duration) map[history.Key][]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	samples := s.window(now, window)
+	result := make(map[history.Key][]float64)
+	for i := range samples {
+		pair := []T{samples[i].value}
+		if s.counter {
+			if i == 0 {
+				continue
+			}
+			pair = []T{samples[i-1].value, samples[i].value}
+		}
+		for key, value := range FlattenStats(s.name, s.aggregate(pair)) {
+			result[key] = append(result[key], value)
+		}
+	}
+	return result
+}
+
+// window - замеры из периода (now-window, now]; для счётчиков вместе с опорным замером перед ним.
+// Вызывается под s.mu.
+func (s *source[T]) window(now time.Time, window time.Duration) []timed[T] {
+	start := now.Add(-window)
+	from := sort.Search(len(s.samples), func(i int) bool { return s.samples[i].at.After(start) })
+	to := sort.Search(len(s.samples), func(i int) bool { return s.samples[i].at.After(now) })
+	if s.counter && from > 0 {
+		from-- // Опорный замер на начало периода
+	}
+	return s.samples[from:to]
+}
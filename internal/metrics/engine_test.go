@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// TestSourceSnapshot - проверяет окно, покрытие и вытеснение замеров по retention.
+func TestSourceSnapshot(t *testing.T) {
+	load := 0.0
+	src := &source[model.LoadAvgRecord]{
+		name: SubsystemLoadAvg,
+		get: func() (model.LoadAvgRecord, error) {
+			load++
+			return model.LoadAvgRecord{Load1min: load}, nil
+		},
+		aggregate:  averageLoadAvg,
+		resolution: time.Second,
+		retention:  5 * time.Second,
+	}
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if stats, cov := src.snapshot(t0, 10*time.Second); stats != nil || cov.Samples != 0 || cov.Covered != 0 {
+		t.Fatalf("snapshot() before samples = %v, %+v, want empty", stats, cov)
+	}
+
+	for i := range 3 {
+		if err := src.collect(t0.Add(time.Duration(i) * time.Second)); err != nil {
+			t.Fatalf("collect() unexpected error: %v", err)
+		}
+	}
+	now := t0.Add(2 * time.Second)
+
+	tests := []struct {
+		name        string
+		window      time.Duration
+		wantSamples int
+		wantCovered time.Duration
+		wantLoad    float64
+	}{
+		{"partial coverage", 10 * time.Second, 3, 3 * time.Second, 2},
+		{"full coverage", 2 * time.Second, 2, 2 * time.Second, 2.5},
+		{"last sample", time.Second, 1, time.Second, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, cov := src.snapshot(now, tt.window)
+			if cov.Samples != tt.wantSamples || cov.Covered != tt.wantCovered {
+				t.Errorf("snapshot() coverage = %+v, want %d samples over %v", cov, tt.wantSamples, tt.wantCovered)
+			}
+			if stats.GetLoadAverage_1Min() != tt.wantLoad {
+				t.Errorf("snapshot() load1 = %v, want %v", stats.GetLoadAverage_1Min(), tt.wantLoad)
+			}
+		})
+	}
+
+	// Замеры старше retention вытесняются
+	for i := 3; i < 10; i++ {
+		if err := src.collect(t0.Add(time.Duration(i) * time.Second)); err != nil {
+			t.Fatalf("collect() unexpected error: %v", err)
+		}
+	}
+	if len(src.samples) != 6 {
+		t.Errorf("collect() kept %d samples, want 6", len(src.samples))
+	}
+}
+
+// TestCounterSourceSnapshot - проверяет, что для счётчиков берётся опорный замер перед окном.
+func TestCounterSourceSnapshot(t *testing.T) {
+	var at time.Time
+	segs := 0.0
+	src := &source[model.NetProtoStats]{
+		name: SubsystemNetProto,
+		get: func() (model.NetProtoStats, error) {
+			segs += 100
+			return model.NetProtoStats{Time: at, Counters: map[string]float64{"Tcp.InSegs": segs}}, nil
+		},
+		aggregate: func(window []model.NetProtoStats) *pb.StatsResponse {
+			return &pb.StatsResponse{NetProtoStats: netProtoRates(window)}
+		},
+		counter:    true,
+		resolution: time.Second,
+		retention:  2 * time.Second,
+	}
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at = t0
+	if err := src.collect(at); err != nil {
+		t.Fatalf("collect() unexpected error: %v", err)
+	}
+	if stats, _ := src.snapshot(at, 5*time.Second); stats != nil {
+		t.Errorf("snapshot() with one counter sample = %v, want nil", stats)
+	}
+
+	for i := 1; i <= 4; i++ {
+		at = t0.Add(time.Duration(i) * time.Second)
+		if err := src.collect(at); err != nil {
+			t.Fatalf("collect() unexpected error: %v", err)
+		}
+	}
+
+	stats, cov := src.snapshot(at, 2*time.Second)
+	if cov.Samples != 2 || cov.Expected != 2 || cov.Covered != 2*time.Second {
+		t.Errorf("snapshot() coverage = %+v, want 2 of 2 samples over 2s", cov)
+	}
+	counters := stats.GetNetProtoStats().GetCounters()
+	if len(counters) != 1 || counters[0].GetRate() != 100 || counters[0].GetTotal() != 500 {
+		t.Errorf("snapshot() counters = %v, want InSegs 100/s total 500", counters)
+	}
+	// Один замер сверх retention остаётся опорным
+	if len(src.samples) != 4 {
+		t.Errorf("collect() kept %d counter samples, want 4", len(src.samples))
+	}
+}
+
+// TestEngineSnapshot - проверяет сборку снимка по включённым подсистемам.
+func TestEngineSnapshot(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Enabled = config.MetricsConfig{LoadAvg: true, CPU: true}
+	log, _ := logger.New(config.LoggerConfig{Level: "ERROR"})
+
+	reader := MockFS{Files: map[string][]byte{
+		"/proc/loadavg": []byte("0.50 0.40 0.30 1/100 12345"),
+		"/proc/stat":    []byte("cpu  100 0 50 800 50 0 0 0 0 0\n"),
+	}}
+	// CPU считается по счётчикам /proc/stat, команды движку не нужны
+	engine := NewEngine(cfg, log, reader, nil)
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, stat := range []string{"cpu  100 0 50 800 50 0 0 0 0 0\n", "cpu  105 0 60 885 50 0 0 0 0 0\n"} {
+		reader.Files["/proc/stat"] = []byte(stat)
+		for _, src := range engine.sources {
+			if err := src.collect(t0.Add(time.Duration(i) * time.Second)); err != nil {
+				t.Fatalf("collect() %s unexpected error: %v", src.subsystem(), err)
+			}
+		}
+	}
+
+	stats, coverage := engine.Snapshot(t0.Add(time.Second), 15*time.Second, cfg.Enabled)
+	if len(coverage) != 2 {
+		t.Fatalf("Snapshot() coverage = %+v, want 2 subsystems", coverage)
+	}
+	// Load average - по двум замерам, CPU - по разности счётчиков между ними
+	for _, c := range coverage {
+		want := 2
+		if c.Subsystem == SubsystemCPU {
+			want = 1
+		}
+		if c.Samples != want {
+			t.Errorf("Snapshot() %s coverage = %+v, want %d samples", c.Subsystem, c, want)
+		}
+	}
+	if stats.GetLoadAverage_1Min() != 0.5 || stats.GetCpuUser() != 5 || stats.GetCpuSystem() != 10 || stats.GetCpuIdle() != 85 {
+		t.Errorf("Snapshot() stats = %v, want load and cpu merged", stats)
+	}
+}
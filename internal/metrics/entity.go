@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/history"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// entityTracker - отслеживает появление и исчезновение сущностей подсистемы (дисков, точек
+// монтирования, интерфейсов). Пропавшая сущность считается удалённой, только если не появлялась
+// дольше expire: кратковременное исчезновение (перемонтирование) событий не порождает.
+type entityTracker struct {
+	subsystem string
+	expire    time.Duration
+	started   bool // Первый замер задаёт исходный набор без событий
+	lastSeen  map[string]time.Time
+}
+
+// newEntityTracker - создаёт отслеживание сущностей подсистемы.
+func newEntityTracker(subsystem string, expire time.Duration) *entityTracker {
+	return &entityTracker{subsystem: subsystem, expire: expire, lastSeen: make(map[string]time.Time)}
+}
+
+// observe - учитывает сущности замера в момент now и возвращает события появления и удаления.
+func (t *entityTracker) observe(now time.Time, names []string) []*pb.EntityEvent {
+	var events []*pb.EntityEvent
+	for _, name := range names {
+		if _, ok := t.lastSeen[name]; !ok && t.started {
+			events = append(events, t.event(now, name, pb.EntityEventKind_ENTITY_ADDED))
+		}
+		t.lastSeen[name] = now
+	}
+	t.started = true
+
+	var expired []string
+	for name, seen := range t.lastSeen {
+		if now.Sub(seen) > t.expire {
+			expired = append(expired, name)
+		}
+	}
+	sort.Strings(expired)
+	for _, name := range expired {
+		delete(t.lastSeen, name)
+		events = append(events, t.event(now, name, pb.EntityEventKind_ENTITY_REMOVED))
+	}
+
+	return events
+}
+
+func (t *entityTracker) event(at time.Time, name string, kind pb.EntityEventKind) *pb.EntityEvent {
+	return &pb.EntityEvent{Time: timestamppb.New(at), Subsystem: t.subsystem, Name: name, Kind: kind}
+}
+
+// entityNames - имена сущностей подсистемы по меткам её числовых рядов.
+func entityNames(values map[history.Key]float64) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for key := range values {
+		if key.Label != "" && !seen[key.Label] {
+			seen[key.Label] = true
+			names = append(names, key.Label)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/history"
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+func TestEntityTracker(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newEntityTracker(SubsystemDisk, 10*time.Second)
+
+	tests := []struct {
+		name  string
+		at    time.Duration
+		names []string
+		want  []string // "+имя" - появление, "-имя" - удаление
+	}{
+		{"initial set without events", 0, []string{"sda", "sdb"}, nil},
+		{"new device", time.Second, []string{"sda", "sdb", "sdc"}, []string{"+sdc"}},
+		{"short absence", 5 * time.Second, []string{"sda", "sdc"}, nil},
+		{"back within expire", 8 * time.Second, []string{"sda", "sdb", "sdc"}, nil},
+		{"expired", 20 * time.Second, []string{"sda"}, []string{"-sdb", "-sdc"}},
+		{"re-attached", 21 * time.Second, []string{"sda", "sdc"}, []string{"+sdc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := tracker.observe(t0.Add(tt.at), tt.names)
+			var got []string
+			for _, e := range events {
+				sign := "+"
+				if e.GetKind() == pb.EntityEventKind_ENTITY_REMOVED {
+					sign = "-"
+				}
+				got = append(got, sign+e.GetName())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("observe() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("observe() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestEngineEvents - проверяет журнал событий движка и выборку по периоду и подсистемам.
+func TestEngineEvents(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := config.NewConfig()
+	cfg.Lifecycle.Expire = 5
+	e := NewEngine(cfg, nil, MockFS{}, nil)
+
+	disk := func(labels ...string) map[history.Key]float64 {
+		values := make(map[history.Key]float64)
+		for _, l := range labels {
+			values[history.Key{Subsystem: SubsystemDisk, Metric: "tps", Label: l}] = 1
+		}
+		return values
+	}
+	e.observeEntities(SubsystemDisk, t0, disk("sda"))
+	e.observeEntities(SubsystemDisk, t0.Add(time.Second), disk("sda", "sdb"))
+	e.observeEntities(SubsystemDisk, t0.Add(10*time.Second), disk("sda"))
+
+	if got := e.Events(t0, t0.Add(time.Minute), config.MetricsConfig{Disk: true}); len(got) != 2 {
+		t.Fatalf("Events() = %v, want added and removed sdb", got)
+	}
+	if got := e.Events(t0.Add(time.Second), t0.Add(time.Minute), config.MetricsConfig{Disk: true}); len(got) != 1 ||
+		got[0].GetKind() != pb.EntityEventKind_ENTITY_REMOVED {
+		t.Errorf("Events() after addition = %v, want only removal", got)
+	}
+	if got := e.Events(t0, t0.Add(time.Minute), config.MetricsConfig{CPU: true}); len(got) != 0 {
+		t.Errorf("Events() of unselected subsystem = %v, want none", got)
+	}
+}
+
+func TestAverageFilesystemStatsPartial(t *testing.T) {
+	root := model.FilesystemStats{Filesystem: "/dev/sda1", MountPoint: "/", UsedPercent: 40}
+	usb := model.FilesystemStats{Filesystem: "/dev/sdc1", MountPoint: "/media/usb", UsedPercent: 10}
+	window := [][]model.FilesystemStats{{root}, {root}, {root, usb}}
+
+	for _, fs := range averageFilesystemStats(groupFilesystemStats(window), len(window)) {
+		if want := fs.GetMountpoint() == "/media/usb"; fs.GetPartial() != want {
+			t.Errorf("averageFilesystemStats() %s partial = %v, want %v", fs.GetMountpoint(), fs.GetPartial(), want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"math"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/history"
+)
+
+// ewmaValue - сглаженное значение ряда и время последнего замера.
+type ewmaValue struct {
+	value float64
+	at    time.Time
+}
+
+// ewma - экспоненциально взвешенные скользящие средние всех рядов с общим периодом полураспада.
+// Вес замера убывает вдвое за каждый halfLife, поэтому старые замеры не выпадают из среднего скачком.
+type ewma struct {
+	halfLife time.Duration
+	lastUsed time.Time // Последний запрос клиента с этим периодом полураспада
+	values   map[history.Key]ewmaValue
+}
+
+// newEWMA - создаёт пустое состояние EWMA.
+func newEWMA(halfLife time.Duration, now time.Time) *ewma {
+	return &ewma{halfLife: halfLife, lastUsed: now, values: make(map[history.Key]ewmaValue)}
+}
+
+// update - учитывает замер подсистемы subsystem в момент at. Первый замер ряда принимается как есть;
+// ряды подсистемы, отсутствующие в замере (отключённый диск, интерфейс), отбрасываются.
+func (w *ewma) update(subsystem string, at time.Time, values map[history.Key]float64) {
+	for key := range w.values {
+		if _, ok := values[key]; key.Subsystem == subsystem && !ok {
+			delete(w.values, key)
+		}
+	}
+
+	for key, value := range values {
+		prev, ok := w.values[key]
+		if ok && at.After(prev.at) {
+			alpha := 1 - math.Exp2(-float64(at.Sub(prev.at))/float64(w.halfLife))
+			value = prev.value + alpha*(value-prev.value)
+		} else if ok {
+			continue
+		}
+		w.values[key] = ewmaValue{value: value, at: at}
+	}
+}
+
+// get - сглаженное значение ряда; для неизвестного ряда возвращается fallback.
+func (w *ewma) get(key history.Key, fallback float64) float64 {
+	if v, ok := w.values[key]; ok {
+		return round(v.value)
+	}
+	return fallback
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/history"
+	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
+)
+
+func TestEWMAUpdate(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	idle := history.Key{Subsystem: SubsystemCPU, Metric: "idle"}
+	sda := history.Key{Subsystem: SubsystemDisk, Metric: "tps", Label: "sda"}
+	sdb := history.Key{Subsystem: SubsystemDisk, Metric: "tps", Label: "sdb"}
+
+	w := newEWMA(10*time.Second, t0)
+	w.update(SubsystemCPU, t0, map[history.Key]float64{idle: 100})
+	w.update(SubsystemDisk, t0, map[history.Key]float64{sda: 10, sdb: 20})
+
+	tests := []struct {
+		name string
+		at   time.Duration
+		in   float64
+		want float64
+	}{
+		{"one half life", 10 * time.Second, 0, 50},
+		{"two half lives", 30 * time.Second, 0, 12.5},
+		{"stale sample ignored", 20 * time.Second, 1000, 12.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w.update(SubsystemCPU, t0.Add(tt.at), map[history.Key]float64{idle: tt.in})
+			if got := w.get(idle, -1); got != tt.want {
+				t.Errorf("get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Пропавшее устройство забывается, ряды других подсистем не трогаются
+	w.update(SubsystemDisk, t0.Add(time.Second), map[history.Key]float64{sda: 10})
+	if got := w.get(sdb, -1); got != -1 {
+		t.Errorf("get() removed device = %v, want fallback", got)
+	}
+	if got := w.get(idle, -1); got != 12.5 {
+		t.Errorf("get() other subsystem = %v, want 12.5", got)
+	}
+}
+
+// TestEngineEWMASnapshot - проверяет, что EWMA доступна сразу и сглаживает последующие замеры.
+func TestEngineEWMASnapshot(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Enabled = config.MetricsConfig{LoadAvg: true}
+	log, _ := logger.New(config.LoggerConfig{Level: "ERROR"})
+
+	reader := MockFS{Files: map[string][]byte{"/proc/loadavg": []byte("1.00 0.40 0.30 1/100 12345")}}
+	engine := NewEngine(cfg, log, reader, nil)
+	src := engine.sources[0]
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := src.collect(t0); err != nil {
+		t.Fatalf("collect() unexpected error: %v", err)
+	}
+
+	// Первый запрос заводит состояние по последнему замеру
+	stats, coverage := engine.EWMASnapshot(t0, 2*time.Second, cfg.Enabled)
+	if stats.GetLoadAverage_1Min() != 1 || len(coverage) != 1 || coverage[0].Samples != 1 {
+		t.Fatalf("EWMASnapshot() = %v, %+v, want load 1 from one sample", stats, coverage)
+	}
+
+	// Через период полураспада новое значение весит половину
+	reader.Files["/proc/loadavg"] = []byte("3.00 0.40 0.30 1/100 12345")
+	at := t0.Add(2 * time.Second)
+	if err := src.collect(at); err != nil {
+		t.Fatalf("collect() unexpected error: %v", err)
+	}
+	engine.updateEWMA(SubsystemLoadAvg, at, FlattenStats(SubsystemLoadAvg, src.latest()))
+
+	stats, _ = engine.EWMASnapshot(at, 2*time.Second, cfg.Enabled)
+	if stats.GetLoadAverage_1Min() != 2 {
+		t.Errorf("EWMASnapshot() load1 = %v, want 2", stats.GetLoadAverage_1Min())
+	}
+
+	// Состояние, которое давно не запрашивали, забывается
+	engine.updateEWMA(SubsystemLoadAvg, at.Add(engine.retention+time.Second), nil)
+	if len(engine.ewma) != 0 {
+		t.Errorf("updateEWMA() kept %d unused states, want 0", len(engine.ewma))
+	}
+}
@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// averageFDStats - усредняет историю замеров дескрипторов.
+// Процессы усредняются по тем замерам, в которых они присутствовали.
+func averageFDStats(history []model.FDStats, cfg config.FDConfig) *pb.FDStats {
+	type procSum struct {
+		command string
+		openFDs float64
+		limit   float64
+		count   float64
+	}
+
+	var sumAllocated, sumMax float64
+	procs := make(map[int]*procSum)
+	for _, stat := range history {
+		sumAllocated += stat.Allocated
+		sumMax += stat.Max
+		for _, p := range stat.Processes {
+			s, ok := procs[p.PID]
+			if !ok {
+				s = &procSum{}
+				procs[p.PID] = s
+			}
+			s.command = p.Command
+			s.openFDs += p.OpenFDs
+			s.limit += p.Limit
+			s.count++
+		}
+	}
+	count := float64(len(history))
+
+	result := &pb.FDStats{
+		Allocated: round(sumAllocated / count),
+		Max:       round(sumMax / count),
+	}
+	if result.Max > 0 {
+		result.UsedPercent = round(result.Allocated / result.Max * 100)
+	}
+
+	processes := make([]*pb.ProcessFDStats, 0, len(procs))
+	for pid, s := range procs {
+		p := &pb.ProcessFDStats{
+			Pid:     int32(pid), //nolint:gosec
+			Command: s.command,
+			OpenFds: round(s.openFDs / s.count),
+			Limit:   round(s.limit / s.count),
+		}
+		if p.Limit > 0 {
+			p.UsedPercent = round(p.OpenFds / p.Limit * 100)
+		}
+		p.OverThreshold = cfg.ThresholdPercent > 0 && p.UsedPercent >= cfg.ThresholdPercent
+		processes = append(processes, p)
+	}
+
+	// Сортируем по убыванию заполненности лимита, при равенстве - по количеству дескрипторов
+	sort.Slice(processes, func(i, j int) bool {
+		if processes[i].UsedPercent != processes[j].UsedPercent {
+			return processes[i].UsedPercent > processes[j].UsedPercent
+		}
+		if processes[i].OpenFds != processes[j].OpenFds {
+			return processes[i].OpenFds > processes[j].OpenFds
+		}
+		return processes[i].Pid < processes[j].Pid
+	})
+
+	// Оставляем top_n процессов, но всегда отдаём все превысившие порог
+	limit := cfg.TopN
+	for limit < len(processes) && processes[limit].OverThreshold {
+		limit++
+	}
+	if limit < len(processes) {
+		processes = processes[:limit]
+	}
+	result.Processes = processes
+
+	return result
+}
+
+// GetFDStats - получает статистику файловых дескрипторов из /proc.
+func GetFDStats(reader FSReader) (model.FDStats, error) {
+	data, err := reader.ReadFile("/proc/sys/fs/file-nr")
+	if err != nil {
+		return model.FDStats{}, fmt.Errorf("failed to read file-nr: %w", err)
+	}
+
+	// Формат: <выделено> <выделено, но не используется> <максимум>
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return model.FDStats{}, fmt.Errorf("invalid file-nr format")
+	}
+	allocated, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return model.FDStats{}, fmt.Errorf("failed to parse allocated: %w", err)
+	}
+	unused, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return model.FDStats{}, fmt.Errorf("failed to parse unused: %w", err)
+	}
+	maxFiles, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return model.FDStats{}, fmt.Errorf("failed to parse file-max: %w", err)
+	}
+
+	entries, err := reader.ReadDir("/proc")
+	if err != nil {
+		return model.FDStats{}, fmt.Errorf("failed to read /proc: %w", err)
+	}
+
+	var processes []model.ProcessFDStats
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry)
+		if err != nil {
+			continue // Не процесс
+		}
+
+		// Процесс мог завершиться или быть недоступен без прав - пропускаем
+		fds, err := reader.ReadDir(filepath.Join("/proc", entry, "fd"))
+		if err != nil {
+			continue
+		}
+		limitsData, err := reader.ReadFile(filepath.Join("/proc", entry, "limits"))
+		if err != nil {
+			continue
+		}
+		limit, err := parseNoFileLimit(limitsData)
+		if err != nil {
+			continue
+		}
+		comm, _ := reader.ReadFile(filepath.Join("/proc", entry, "comm"))
+
+		processes = append(processes, model.ProcessFDStats{
+			PID:     pid,
+			Command: strings.TrimSpace(string(comm)),
+			OpenFDs: float64(len(fds)),
+			Limit:   limit,
+		})
+	}
+
+	return model.FDStats{
+		Allocated: allocated - unused,
+		Max:       maxFiles,
+		Processes: processes,
+	}, nil
+}
+
+// parseNoFileLimit - извлекает мягкий лимит "Max open files" из /proc/[pid]/limits.
+// Для значения "unlimited" возвращает 0.
+func parseNoFileLimit(data []byte) (float64, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		rest, ok := strings.CutPrefix(line, "Max open files")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest) // Soft Limit, Hard Limit, Units
+		if len(fields) < 1 {
+			break
+		}
+		if fields[0] == "unlimited" {
+			return 0, nil
+		}
+		return strconv.ParseFloat(fields[0], 64)
+	}
+	return 0, fmt.Errorf("max open files not found in limits")
+}
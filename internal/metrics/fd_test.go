@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"os"
+	"testing"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+)
+
+// MockFS - мок файловой системы (/proc, /sys) для FSReader.
+type MockFS struct {
+	Files map[string][]byte   // Содержимое файлов по полному пути
+	Dirs  map[string][]string // Содержимое каталогов по полному пути
+}
+
+func (m MockFS) ReadFile(filename string) ([]byte, error) {
+	data, ok := m.Files[filename]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+func (m MockFS) ReadDir(dirname string) ([]string, error) {
+	names, ok := m.Dirs[dirname]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return names, nil
+}
+
+const limitsHeader = "Limit                     Soft Limit           Hard Limit           Units     \n"
+
+func fdFS(allocated string, fds int) MockFS {
+	fdList := make([]string, fds)
+	for i := range fdList {
+		fdList[i] = "fd"
+	}
+	return MockFS{
+		Files: map[string][]byte{
+			"/proc/sys/fs/file-nr": []byte(allocated + "\t0\t1000\n"),
+			"/proc/1/limits":       []byte(limitsHeader + "Max open files            1024                 4096                 files     \n"),
+			"/proc/1/comm":         []byte("init\n"),
+			"/proc/42/limits":      []byte(limitsHeader + "Max open files            10                   10                   files     \n"),
+			"/proc/42/comm":        []byte("leaky\n"),
+		},
+		Dirs: map[string][]string{
+			"/proc":       {"1", "42", "self", "sys"},
+			"/proc/1/fd":  {"0", "1", "2"},
+			"/proc/42/fd": fdList,
+		},
+	}
+}
+
+func TestGetFDStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		reader    FSReader
+		wantStats model.FDStats
+		wantErr   bool
+	}{
+		{
+			name:   "valid data",
+			reader: fdFS("200", 9),
+			wantStats: model.FDStats{
+				Allocated: 200,
+				Max:       1000,
+				Processes: []model.ProcessFDStats{
+					{PID: 1, Command: "init", OpenFDs: 3, Limit: 1024},
+					{PID: 42, Command: "leaky", OpenFDs: 9, Limit: 10},
+				},
+			},
+		},
+		{
+			name: "unreadable process is skipped",
+			reader: MockFS{
+				Files: map[string][]byte{"/proc/sys/fs/file-nr": []byte("10 2 100")},
+				Dirs:  map[string][]string{"/proc": {"7"}},
+			},
+			wantStats: model.FDStats{Allocated: 8, Max: 100},
+		},
+		{
+			name:    "invalid file-nr",
+			reader:  MockFS{Files: map[string][]byte{"/proc/sys/fs/file-nr": []byte("10")}},
+			wantErr: true,
+		},
+		{
+			name:    "file-nr missing",
+			reader:  MockFS{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := GetFDStats(tt.reader)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetFDStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if stats.Allocated != tt.wantStats.Allocated || stats.Max != tt.wantStats.Max {
+				t.Errorf("GetFDStats() got = %+v, want %+v", stats, tt.wantStats)
+			}
+			if len(stats.Processes) != len(tt.wantStats.Processes) {
+				t.Fatalf("GetFDStats() got %d processes, want %d", len(stats.Processes), len(tt.wantStats.Processes))
+			}
+			for i, got := range stats.Processes {
+				if got != tt.wantStats.Processes[i] {
+					t.Errorf("GetFDStats() process #%d = %+v, want %+v", i, got, tt.wantStats.Processes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseNoFileLimit(t *testing.T) {
+	limit, err := parseNoFileLimit([]byte(limitsHeader + "Max open files            unlimited            unlimited            files\n"))
+	if err != nil || limit != 0 {
+		t.Errorf("parseNoFileLimit(unlimited) = %v, %v, want 0, nil", limit, err)
+	}
+
+	_, err = parseNoFileLimit([]byte(limitsHeader))
+	if err == nil {
+		t.Errorf("parseNoFileLimit() error = nil, want error")
+	}
+}
+
+func TestAverageFDStats(t *testing.T) {
+	history := []model.FDStats{
+		{Allocated: 100, Max: 1000, Processes: []model.ProcessFDStats{
+			{PID: 1, Command: "a", OpenFDs: 10, Limit: 100},
+			{PID: 2, Command: "b", OpenFDs: 85, Limit: 100},
+		}},
+		{Allocated: 300, Max: 1000, Processes: []model.ProcessFDStats{
+			{PID: 1, Command: "a", OpenFDs: 30, Limit: 100},
+			{PID: 2, Command: "b", OpenFDs: 95, Limit: 100},
+			{PID: 3, Command: "c", OpenFDs: 1, Limit: 100},
+		}},
+	}
+
+	got := averageFDStats(history, config.FDConfig{ThresholdPercent: 80, TopN: 1})
+	if got.Allocated != 200 || got.UsedPercent != 20 {
+		t.Errorf("averageFDStats() system = %v/%v, want 200/20", got.Allocated, got.UsedPercent)
+	}
+
+	// top_n = 1, но процесс над порогом всегда первый
+	if len(got.Processes) != 1 {
+		t.Fatalf("averageFDStats() got %d processes, want 1", len(got.Processes))
+	}
+	p := got.Processes[0]
+	if p.Pid != 2 || p.OpenFds != 90 || !p.OverThreshold {
+		t.Errorf("averageFDStats() process = %+v, want pid 2 with 90 fds over threshold", p)
+	}
+
+	// Все процессы над порогом отдаются даже при top_n = 0
+	got = averageFDStats(history, config.FDConfig{ThresholdPercent: 15, TopN: 0})
+	if len(got.Processes) != 2 {
+		t.Errorf("averageFDStats() got %d processes, want 2", len(got.Processes))
+	}
+}
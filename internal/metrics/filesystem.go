@@ -5,121 +5,58 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/shagrat164/system-monitoring-daemon/internal/config"
-	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
 	"github.com/shagrat164/system-monitoring-daemon/internal/model"
 	pb "github.com/shagrat164/system-monitoring-daemon/proto"
 )
 
-// CollectFilesystemStats - собирает статистику файловых систем и отправляет усреднённые данные в канал.
-func CollectFilesystemStats(ctx context.Context,
-	cfg *config.Config,
-	log *logger.Logger,
-	statsChan chan *pb.StatsResponse,
-	interval, duration int32,
-	cmd Commander,
-) {
-	if !cfg.Enabled.Filesystem {
-		log.Info("Load filesystem collection disabled")
-		return
-	}
-
-	n := time.Duration(interval) * time.Second
-	if n <= 0 {
-		n = 5 * time.Second
-	}
-	m := time.Duration(duration) * time.Second
-	if m <= 0 {
-		m = 15 * time.Second
+// averageFilesystemStats - усредняет историю замеров по каждой точке монтирования.
+// Точка монтирования, у которой меньше samples замеров, помечается как partial.
+func averageFilesystemStats(historyMap map[string][]model.FilesystemStats, samples int) []*pb.FilesystemStats {
+	pbFsStats := make([]*pb.FilesystemStats, 0, len(historyMap))
+	for mp, h := range historyMap {
+		var sumUsedMB, sumUsedPercent, sumInodesUsed, sumInodesPercent float64
+		for _, stat := range h {
+			sumUsedMB += stat.UsedMB
+			sumUsedPercent += stat.UsedPercent
+			sumInodesUsed += stat.InodesUsed
+			sumInodesPercent += stat.InodesPercent
+		}
+		count := float64(len(h))
+		pbFsStats = append(pbFsStats, &pb.FilesystemStats{
+			Filesystem:    h[0].Filesystem,
+			Mountpoint:    mp,
+			UsedMb:        round(sumUsedMB / count),
+			UsedPercent:   round(sumUsedPercent / count),
+			InodesUsed:    round(sumInodesUsed / count),
+			InodesPercent: round(sumInodesPercent / count),
+			Partial:       len(h) < samples,
+		})
 	}
+	return pbFsStats
+}
 
-	maxHistory := int(m / n)
+// groupFilesystemStats - раскладывает последовательность замеров по точкам монтирования.
+func groupFilesystemStats(window [][]model.FilesystemStats) map[string][]model.FilesystemStats {
 	historyMap := make(map[string][]model.FilesystemStats)
-
-	ticker := time.NewTicker(n)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		fsStats, err := GetFilesystemStats(cmd)
-		if err != nil {
-			log.Error(fmt.Sprintf("Failed to collect filesystem stats: %v", err))
-			continue
-		}
-
-		// Обновляем историю для каждой файловой системы
+	for _, fsStats := range window {
 		for _, stat := range fsStats {
-			h := historyMap[stat.MountPoint]
-			h = append(h, stat)
-			if len(h) > maxHistory {
-				h = h[1:] // Обрезаем старую запись
-			}
-			historyMap[stat.MountPoint] = h
-		}
-
-		// "Молчим", пока не накопим maxHistory записей для всех файловых систем
-		allReady := true
-		for _, h := range historyMap {
-			if len(h) < maxHistory {
-				allReady = false
-				break
-			}
-		}
-		if !allReady {
-			continue
-		}
-
-		// Формируем усреднённые данные
-		var pbFsStats []*pb.FilesystemStats
-		for mp, h := range historyMap {
-			var sumUsedMB, sumUsedPercent, sumInodesUsed, sumInodesPercent float64
-			for _, stat := range h {
-				sumUsedMB += stat.UsedMB
-				sumUsedPercent += stat.UsedPercent
-				sumInodesUsed += stat.InodesUsed
-				sumInodesPercent += stat.InodesPercent
-			}
-			count := float64(len(h))
-			pbFsStats = append(pbFsStats, &pb.FilesystemStats{
-				Filesystem:    h[0].Filesystem,
-				Mountpoint:    mp,
-				UsedMb:        round(sumUsedMB / count),
-				UsedPercent:   round(sumUsedPercent / count),
-				InodesUsed:    round(sumInodesUsed / count),
-				InodesPercent: round(sumInodesPercent / count),
-			})
-		}
-
-		if len(pbFsStats) == 0 {
-			continue
-		}
-
-		stats := &pb.StatsResponse{
-			FilesystemStats: pbFsStats,
-		}
-
-		// log.Debug(fmt.Sprintf("Filesystem len(historyMap): %d", len(historyMap)))
-
-		select {
-		case <-ctx.Done():
-			log.Debug("Gorutine CollectFilesystemStats is done.")
-			return
-		case statsChan <- stats:
+			historyMap[stat.MountPoint] = append(historyMap[stat.MountPoint], stat)
 		}
 	}
+	return historyMap
 }
 
 // GetFilesystemStats - получает статистику файловых систем с помощью df.
-func GetFilesystemStats(cmd Commander) ([]model.FilesystemStats, error) {
+func GetFilesystemStats(ctx context.Context, cmd Commander) ([]model.FilesystemStats, error) {
 	// Получаем данные об объёмах (df -h)
-	outputH, err := cmd.Run("df", "-h")
+	outputH, err := cmd.RunContext(ctx, "df", "-h")
 	if err != nil {
 		return nil, fmt.Errorf("df -h command failed: %w", err)
 	}
 
 	// Получаем данные об инодах (df -i)
-	outputI, err := cmd.Run("df", "-i")
+	outputI, err := cmd.RunContext(ctx, "df", "-i")
 	if err != nil {
 		return nil, fmt.Errorf("df -i command failed: %w", err)
 	}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"github.com/shagrat164/system-monitoring-daemon/internal/history"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// FlattenStats - раскладывает данные подсистемы на числовые временные ряды
+// "подсистема / метрика / метка" для хранения истории.
+func FlattenStats(subsystem string, stats *pb.StatsResponse) map[history.Key]float64 {
+	values := make(map[history.Key]float64)
+	walkStats(subsystem, stats, func(key history.Key, value float64) float64 {
+		values[key] = value
+		return value
+	})
+	return values
+}
+
+// walkStats - обходит числовые метрики подсистемы и записывает в каждую значение, возвращённое fn.
+// Количество дисков RAID - целые числа, они только читаются.
+func walkStats(subsystem string, stats *pb.StatsResponse, fn func(key history.Key, value float64) float64) {
+	visit := func(metric, label string, value float64) float64 {
+		return fn(history.Key{Subsystem: subsystem, Metric: metric, Label: label}, value)
+	}
+
+	switch subsystem {
+	case SubsystemLoadAvg:
+		stats.LoadAverage_1Min = visit("load_1min", "", stats.GetLoadAverage_1Min())
+		stats.LoadAverage_5Min = visit("load_5min", "", stats.GetLoadAverage_5Min())
+		stats.LoadAverage_15Min = visit("load_15min", "", stats.GetLoadAverage_15Min())
+	case SubsystemCPU:
+		stats.CpuUser = visit("user", "", stats.GetCpuUser())
+		stats.CpuSystem = visit("system", "", stats.GetCpuSystem())
+		stats.CpuIdle = visit("idle", "", stats.GetCpuIdle())
+	case SubsystemDisk:
+		for _, d := range stats.GetDiskStats() {
+			d.Tps = visit("tps", d.GetDevice(), d.GetTps())
+			d.KbRead = visit("kb_read", d.GetDevice(), d.GetKbRead())
+			d.KbWrite = visit("kb_write", d.GetDevice(), d.GetKbWrite())
+			d.KbTotal = visit("kb_total", d.GetDevice(), d.GetKbTotal())
+		}
+	case SubsystemFilesystem:
+		for _, fs := range stats.GetFilesystemStats() {
+			fs.UsedMb = visit("used_mb", fs.GetMountpoint(), fs.GetUsedMb())
+			fs.UsedPercent = visit("used_percent", fs.GetMountpoint(), fs.GetUsedPercent())
+			fs.InodesUsed = visit("inodes_used", fs.GetMountpoint(), fs.GetInodesUsed())
+			fs.InodesPercent = visit("inodes_percent", fs.GetMountpoint(), fs.GetInodesPercent())
+		}
+	case SubsystemFD:
+		if fd := stats.GetFdStats(); fd != nil {
+			fd.Allocated = visit("allocated", "", fd.GetAllocated())
+			fd.Max = visit("max", "", fd.GetMax())
+			fd.UsedPercent = visit("used_percent", "", fd.GetUsedPercent())
+		}
+	case SubsystemNetProto:
+		for _, c := range stats.GetNetProtoStats().GetCounters() {
+			c.Rate = visit(c.GetProtocol()+"."+c.GetName(), "", c.GetRate())
+		}
+	case SubsystemNetIface:
+		for _, i := range stats.GetNetIfaceStats() {
+			i.RxBytesPerSec = visit("rx_bytes_per_sec", i.GetName(), i.GetRxBytesPerSec())
+			i.TxBytesPerSec = visit("tx_bytes_per_sec", i.GetName(), i.GetTxBytesPerSec())
+			i.UtilizationPercent = visit("utilization_percent", i.GetName(), i.GetUtilizationPercent())
+		}
+	case SubsystemRAID:
+		for _, a := range stats.GetRaidArrays() {
+			visit("active_disks", a.GetName(), float64(a.GetActiveDisks()))
+			visit("degraded", a.GetName(), float64(a.GetDegraded()))
+			a.SyncProgress = visit("sync_progress", a.GetName(), a.GetSyncProgress())
+			a.SyncSpeedKbs = visit("sync_speed_kbs", a.GetName(), a.GetSyncSpeedKbs())
+		}
+	case SubsystemDaemon:
+		if d := stats.GetDaemonStats(); d != nil {
+			d.CpuPercent = visit("cpu_percent", "", d.GetCpuPercent())
+			d.RssMb = visit("rss_mb", "", d.GetRssMb())
+			d.Goroutines = visit("goroutines", "", d.GetGoroutines())
+			d.QueueDepth = visit("queue_depth", "", d.GetQueueDepth())
+			for _, s := range d.GetStreams() {
+				visit("streams", s.GetRpc(), float64(s.GetActive()))
+			}
+		}
+	}
+}
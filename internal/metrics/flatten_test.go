@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/history"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+func TestFlattenStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		subsystem string
+		stats     *pb.StatsResponse
+		want      map[history.Key]float64
+	}{
+		{
+			name:      "load average",
+			subsystem: SubsystemLoadAvg,
+			stats:     &pb.StatsResponse{LoadAverage_1Min: 1.5},
+			want: map[history.Key]float64{
+				{Subsystem: SubsystemLoadAvg, Metric: "load_1min"}:  1.5,
+				{Subsystem: SubsystemLoadAvg, Metric: "load_5min"}:  0,
+				{Subsystem: SubsystemLoadAvg, Metric: "load_15min"}: 0,
+			},
+		},
+		{
+			name:      "per interface",
+			subsystem: SubsystemNetIface,
+			stats:     &pb.StatsResponse{NetIfaceStats: []*pb.NetIfaceStats{{Name: "eth0", RxBytesPerSec: 100}}},
+			want: map[history.Key]float64{
+				{Subsystem: SubsystemNetIface, Metric: "rx_bytes_per_sec", Label: "eth0"}:    100,
+				{Subsystem: SubsystemNetIface, Metric: "tx_bytes_per_sec", Label: "eth0"}:    0,
+				{Subsystem: SubsystemNetIface, Metric: "utilization_percent", Label: "eth0"}: 0,
+			},
+		},
+		{
+			name:      "protocol counters",
+			subsystem: SubsystemNetProto,
+			stats: &pb.StatsResponse{NetProtoStats: &pb.NetProtoStats{
+				Counters: []*pb.ProtoCounter{{Protocol: "Tcp", Name: "RetransSegs", Rate: 3}},
+			}},
+			want: map[history.Key]float64{{Subsystem: SubsystemNetProto, Metric: "Tcp.RetransSegs"}: 3},
+		},
+		{
+			name:      "inventory is not a time series",
+			subsystem: SubsystemBlockDevices,
+			stats:     &pb.StatsResponse{BlockDevices: []*pb.BlockDevice{{Name: "sda"}}},
+			want:      map[history.Key]float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlattenStats(tt.subsystem, tt.stats)
+			if len(got) != len(tt.want) {
+				t.Fatalf("FlattenStats() = %v, want %v", got, tt.want)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("FlattenStats()[%+v] = %v, want %v", key, got[key], want)
+				}
+			}
+		})
+	}
+}
@@ -1,20 +1,24 @@
 package metrics
 
 import (
+	"context"
 	"os"
-	"os/exec"
 )
 
 // Commander - интерфейс для выполнения команд.
 type Commander interface {
 	Run(cmd string, args ...string) ([]byte, error)
+	// RunContext - выполняет команду с учётом срока и отмены контекста.
+	RunContext(ctx context.Context, cmd string, args ...string) ([]byte, error)
 }
 
 // RealCommander - реальная реализация для запуска команд.
-type RealCommander struct{}
+type RealCommander struct {
+	MaxOutput int // Ограничение вывода команды, байт (0 - defaultMaxOutput)
+}
 
 func (r RealCommander) Run(cmd string, args ...string) ([]byte, error) {
-	return exec.Command(cmd, args...).Output()
+	return r.RunContext(context.Background(), cmd, args...)
 }
 
 // FileReader - интерфейс для чтения файлов.
@@ -22,9 +26,33 @@ type FileReader interface {
 	ReadFile(filename string) ([]byte, error)
 }
 
-// RealFileReader - реальная реализация интерфейса FileReader.
+// DirReader - интерфейс для чтения содержимого каталогов.
+type DirReader interface {
+	ReadDir(dirname string) ([]string, error)
+}
+
+// FSReader - интерфейс для чтения файлов и каталогов (/proc, /sys).
+type FSReader interface {
+	FileReader
+	DirReader
+}
+
+// RealFileReader - реальная реализация интерфейсов FileReader и DirReader.
 type RealFileReader struct{}
 
 func (r RealFileReader) ReadFile(filename string) ([]byte, error) {
 	return os.ReadFile(filename)
 }
+
+// ReadDir - возвращает имена записей каталога.
+func (r RealFileReader) ReadDir(dirname string) ([]string, error) {
+	entries, err := os.ReadDir(dirname)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names, nil
+}
@@ -1,97 +1,28 @@
 package metrics
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/shagrat164/system-monitoring-daemon/internal/config"
-	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
 	"github.com/shagrat164/system-monitoring-daemon/internal/model"
 	pb "github.com/shagrat164/system-monitoring-daemon/proto"
 )
 
-// CollectLoadAvg - собирает статистику load average и отправляет усреднённые данные в канал.
-func CollectLoadAvg(ctx context.Context,
-	cfg *config.Config,
-	log *logger.Logger,
-	loadChan chan *pb.StatsResponse,
-	interval, duration int32,
-	reader FileReader,
-) {
-	if !cfg.Enabled.LoadAvg {
-		log.Info("Load average collection disabled")
-		return
+// averageLoadAvg - усредняет историю замеров load average.
+func averageLoadAvg(history []model.LoadAvgRecord) *pb.StatsResponse {
+	var sum1, sum5, sum15 float64
+	for _, stat := range history {
+		sum1 += stat.Load1min
+		sum5 += stat.Load5min
+		sum15 += stat.Load15min
 	}
+	count := float64(len(history))
 
-	n := time.Duration(interval) * time.Second
-	if n <= 0 {
-		n = 5 * time.Second
-	}
-
-	m := time.Duration(duration) * time.Second
-	if m <= 0 {
-		m = 15 * time.Second
-	}
-
-	maxHistory := int(m / n)          // Максимальное количество записей в истории
-	var history []model.LoadAvgRecord //nolint:prealloc
-
-	ticker := time.NewTicker(n)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		load1, load5, load15, err := GetLoadAvg(reader)
-		if err != nil {
-			log.Error(fmt.Sprintf("Failed to collect load average: %v", err))
-			continue
-		}
-
-		history = append(history, model.LoadAvgRecord{
-			Load1min:  load1,
-			Load5min:  load5,
-			Load15min: load15,
-		})
-
-		if len(history) > maxHistory {
-			history = history[1:] // Cut off first record
-		}
-		if len(history) == 0 {
-			continue
-		}
-
-		// "Молчим", пока не накопим maxHistory записей
-		if len(history) < maxHistory {
-			continue
-		}
-
-		var sum1, sum5, sum15 float64
-		for _, stat := range history {
-			sum1 += stat.Load1min
-			sum5 += stat.Load5min
-			sum15 += stat.Load15min
-		}
-		count := float64(len(history))
-		if count == 0 {
-			continue
-		}
-
-		stats := &pb.StatsResponse{
-			LoadAverage_1Min:  round(sum1 / count),
-			LoadAverage_5Min:  round(sum5 / count),
-			LoadAverage_15Min: round(sum15 / count),
-		}
-
-		// log.Debug(fmt.Sprintf("Loadavg len(history): %d", len(history)))
-
-		select {
-		case <-ctx.Done():
-			log.Debug("Gorutine CollectLoadAvg is done.")
-			return
-		case loadChan <- stats:
-		}
+	return &pb.StatsResponse{
+		LoadAverage_1Min:  round(sum1 / count),
+		LoadAverage_5Min:  round(sum5 / count),
+		LoadAverage_15Min: round(sum15 / count),
 	}
 }
 
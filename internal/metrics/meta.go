@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"os"
+	"strings"
+)
+
+// HostInfo - возвращает имя хоста и идентификатор текущей загрузки ядра.
+func HostInfo(reader FileReader) (hostname, bootID string) {
+	hostname, _ = os.Hostname()
+	if data, err := reader.ReadFile("/proc/sys/kernel/random/boot_id"); err == nil {
+		bootID = strings.TrimSpace(string(data))
+	}
+	return hostname, bootID
+}
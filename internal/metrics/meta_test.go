@@ -0,0 +1,15 @@
+package metrics
+
+import "testing"
+
+func TestHostInfo(t *testing.T) {
+	reader := MockFS{Files: map[string][]byte{
+		"/proc/sys/kernel/random/boot_id": []byte("4f1c2d9e-0b7a-4c53-9d0e-6a1f2b3c4d5e\n"),
+	}}
+	if _, bootID := HostInfo(reader); bootID != "4f1c2d9e-0b7a-4c53-9d0e-6a1f2b3c4d5e" {
+		t.Errorf("HostInfo() boot id = %q", bootID)
+	}
+	if _, bootID := HostInfo(MockFS{}); bootID != "" {
+		t.Errorf("HostInfo() boot id = %q, want empty without /proc", bootID)
+	}
+}
@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// netIfaceRates - вычисляет пропускную способность интерфейсов по истории замеров.
+// Скорость каждого интерфейса считается по его собственным крайним замерам: интерфейс,
+// появившийся внутри периода, считается с момента появления и помечается как partial.
+// Метаданные линка (скорость, дуплекс, MTU, состояние) берутся из последнего замера.
+func netIfaceRates(history []model.NetIfaceStats) []*pb.NetIfaceStats {
+	last := history[len(history)-1]
+
+	var result []*pb.NetIfaceStats
+	for _, iface := range last.Interfaces {
+		var (
+			since   time.Time
+			rx, tx  []float64
+			carrier []float64
+		)
+		for _, h := range history {
+			i := slices.IndexFunc(h.Interfaces, func(i model.NetIface) bool { return i.Name == iface.Name })
+			if i < 0 {
+				// Интерфейс пропадал - считаем заново с момента появления
+				rx, tx, carrier = nil, nil, nil
+				continue
+			}
+			if rx == nil {
+				since = h.Time
+			}
+			rx = append(rx, h.Interfaces[i].RxBytes)
+			tx = append(tx, h.Interfaces[i].TxBytes)
+			carrier = append(carrier, h.Interfaces[i].CarrierChanges)
+		}
+
+		stats := &pb.NetIfaceStats{
+			Name:                 iface.Name,
+			SpeedMbps:            iface.SpeedMbps,
+			Duplex:               iface.Duplex,
+			Mtu:                  iface.MTU,
+			Operstate:            iface.OperState,
+			CarrierChanges:       iface.CarrierChanges,
+			CarrierChangesPeriod: sumIncrements(carrier),
+			Partial:              since.After(history[0].Time),
+		}
+		if elapsed := last.Time.Sub(since).Seconds(); elapsed > 0 {
+			stats.RxBytesPerSec = round(sumIncrements(rx) / elapsed)
+			stats.TxBytesPerSec = round(sumIncrements(tx) / elapsed)
+		}
+		stats.UtilizationPercent = linkUtilization(stats.RxBytesPerSec, stats.TxBytesPerSec, iface.SpeedMbps, iface.Duplex)
+
+		result = append(result, stats)
+	}
+
+	return result
+}
+
+// linkUtilization - загрузка линка в процентах от согласованной скорости.
+// В полном дуплексе направления независимы, поэтому берётся максимальное.
+func linkUtilization(rxBytesPerSec, txBytesPerSec, speedMbps float64, duplex string) float64 {
+	if speedMbps <= 0 {
+		return 0 // Скорость неизвестна (виртуальный интерфейс или линк опущен)
+	}
+
+	bits := max(rxBytesPerSec, txBytesPerSec) * 8
+	if duplex == "half" {
+		bits = (rxBytesPerSec + txBytesPerSec) * 8
+	}
+	return round(bits / (speedMbps * 1e6) * 100)
+}
+
+// GetNetIfaceStats - читает счётчики интерфейсов из /proc/net/dev и метаданные линка из /sys/class/net.
+func GetNetIfaceStats(reader FileReader) (model.NetIfaceStats, error) {
+	data, err := reader.ReadFile("/proc/net/dev")
+	if err != nil {
+		return model.NetIfaceStats{}, fmt.Errorf("failed to read /proc/net/dev: %w", err)
+	}
+
+	stats := model.NetIfaceStats{Time: time.Now()}
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		if i < 2 {
+			continue // Пропускаем две строки заголовка
+		}
+
+		name, counters, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(counters)
+		if len(fields) < 16 {
+			continue
+		}
+		rxBytes, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return model.NetIfaceStats{}, fmt.Errorf("failed to parse rx bytes: %w", err)
+		}
+		txBytes, err := strconv.ParseFloat(fields[8], 64)
+		if err != nil {
+			return model.NetIfaceStats{}, fmt.Errorf("failed to parse tx bytes: %w", err)
+		}
+
+		iface := model.NetIface{
+			Name:    strings.TrimSpace(name),
+			RxBytes: rxBytes,
+			TxBytes: txBytes,
+		}
+		readLinkInfo(reader, &iface)
+		stats.Interfaces = append(stats.Interfaces, iface)
+	}
+
+	if len(stats.Interfaces) == 0 {
+		return model.NetIfaceStats{}, fmt.Errorf("no valid interfaces found in /proc/net/dev")
+	}
+
+	return stats, nil
+}
+
+// readLinkInfo - заполняет метаданные линка из /sys/class/net/<if>.
+// Для опущенных и виртуальных интерфейсов часть файлов не читается - поля остаются пустыми.
+func readLinkInfo(reader FileReader, iface *model.NetIface) {
+	dir := filepath.Join("/sys/class/net", iface.Name)
+	read := func(name string) string {
+		data, err := reader.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(data))
+	}
+
+	if speed, err := strconv.ParseFloat(read("speed"), 64); err == nil && speed > 0 {
+		iface.SpeedMbps = speed
+	}
+	iface.Duplex = read("duplex")
+	iface.MTU, _ = strconv.ParseFloat(read("mtu"), 64)
+	iface.OperState = read("operstate")
+	iface.CarrierChanges, _ = strconv.ParseFloat(read("carrier_changes"), 64)
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+)
+
+func TestGetNetIfaceStats(t *testing.T) {
+	reader := MockFS{
+		Files: map[string][]byte{
+			"/proc/net/dev": []byte(`Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:    1000      10    0    0    0     0          0         0     1000      10    0    0    0     0       0          0
+  eth0: 5000000    4000    0    0    0     0          0         0  2000000    3000    0    0    0     0       0          0
+`),
+			"/sys/class/net/eth0/speed":           []byte("100\n"),
+			"/sys/class/net/eth0/duplex":          []byte("full\n"),
+			"/sys/class/net/eth0/mtu":             []byte("1500\n"),
+			"/sys/class/net/eth0/operstate":       []byte("up\n"),
+			"/sys/class/net/eth0/carrier_changes": []byte("7\n"),
+			"/sys/class/net/lo/speed":             []byte("-1\n"),
+			"/sys/class/net/lo/mtu":               []byte("65536\n"),
+			"/sys/class/net/lo/operstate":         []byte("unknown\n"),
+		},
+	}
+
+	stats, err := GetNetIfaceStats(reader)
+	if err != nil {
+		t.Fatalf("GetNetIfaceStats() unexpected error: %v", err)
+	}
+
+	want := []model.NetIface{
+		{Name: "lo", RxBytes: 1000, TxBytes: 1000, MTU: 65536, OperState: "unknown"},
+		{
+			Name: "eth0", RxBytes: 5000000, TxBytes: 2000000, SpeedMbps: 100,
+			Duplex: "full", MTU: 1500, OperState: "up", CarrierChanges: 7,
+		},
+	}
+	if len(stats.Interfaces) != len(want) {
+		t.Fatalf("GetNetIfaceStats() got %d interfaces, want %d", len(stats.Interfaces), len(want))
+	}
+	for i, got := range stats.Interfaces {
+		if got != want[i] {
+			t.Errorf("GetNetIfaceStats() interface #%d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	if _, err := GetNetIfaceStats(MockFS{}); err == nil {
+		t.Errorf("GetNetIfaceStats() error = nil, want error for missing /proc/net/dev")
+	}
+}
+
+func TestNetIfaceRates(t *testing.T) {
+	start := time.Now()
+	iface := func(rx, tx, carrier float64) []model.NetIface {
+		return []model.NetIface{{
+			Name: "eth0", RxBytes: rx, TxBytes: tx, SpeedMbps: 100,
+			Duplex: "full", MTU: 1500, OperState: "up", CarrierChanges: carrier,
+		}}
+	}
+	history := []model.NetIfaceStats{
+		{Time: start, Interfaces: iface(0, 0, 4)},
+		{Time: start.Add(5 * time.Second), Interfaces: iface(25_000_000, 5_000_000, 6)},
+		{Time: start.Add(10 * time.Second), Interfaces: iface(62_500_000, 10_000_000, 6)},
+	}
+
+	got := netIfaceRates(history)
+	if len(got) != 1 {
+		t.Fatalf("netIfaceRates() got %d interfaces, want 1", len(got))
+	}
+
+	i := got[0]
+	const epsilon = 0.01
+	if math.Abs(i.RxBytesPerSec-6_250_000) > epsilon || math.Abs(i.TxBytesPerSec-1_000_000) > epsilon {
+		t.Errorf("netIfaceRates() rx/tx = %v/%v, want 6250000/1000000", i.RxBytesPerSec, i.TxBytesPerSec)
+	}
+	// 6.25 МБ/с = 50 Мбит/с на линке 100 Мбит/с
+	if math.Abs(i.UtilizationPercent-50) > epsilon {
+		t.Errorf("netIfaceRates() utilization = %v, want 50", i.UtilizationPercent)
+	}
+	if i.CarrierChanges != 6 || i.CarrierChangesPeriod != 2 {
+		t.Errorf("netIfaceRates() carrier changes = %v/%v, want 6/2", i.CarrierChanges, i.CarrierChangesPeriod)
+	}
+}
+
+func TestNetIfaceRatesPartial(t *testing.T) {
+	start := time.Now()
+	eth0 := model.NetIface{Name: "eth0", RxBytes: 0}
+	history := []model.NetIfaceStats{
+		{Time: start, Interfaces: []model.NetIface{eth0}},
+		{Time: start.Add(5 * time.Second), Interfaces: []model.NetIface{eth0}},
+		{Time: start.Add(10 * time.Second), Interfaces: []model.NetIface{eth0, {Name: "wg0", RxBytes: 1000}}},
+		{Time: start.Add(15 * time.Second), Interfaces: []model.NetIface{eth0, {Name: "wg0", RxBytes: 6000}}},
+	}
+
+	got := netIfaceRates(history)
+	if len(got) != 2 {
+		t.Fatalf("netIfaceRates() got %d interfaces, want 2", len(got))
+	}
+	if got[0].GetPartial() {
+		t.Errorf("netIfaceRates() eth0 partial = true, want false")
+	}
+	// Скорость wg0 считается за 5 секунд с момента появления, а не за всё окно
+	wg0 := got[1]
+	if !wg0.GetPartial() || math.Abs(wg0.GetRxBytesPerSec()-1000) > 0.01 {
+		t.Errorf("netIfaceRates() wg0 partial/rx = %v/%v, want true/1000", wg0.GetPartial(), wg0.GetRxBytesPerSec())
+	}
+}
+
+func TestLinkUtilization(t *testing.T) {
+	tests := []struct {
+		name   string
+		rx, tx float64
+		speed  float64
+		duplex string
+		want   float64
+	}{
+		{"full duplex uses max direction", 1_250_000, 625_000, 100, "full", 10},
+		{"half duplex sums directions", 1_250_000, 625_000, 100, "half", 15},
+		{"unknown speed", 1_250_000, 0, 0, "unknown", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := linkUtilization(tt.rx, tt.tx, tt.speed, tt.duplex); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("linkUtilization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
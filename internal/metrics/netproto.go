@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// netProtoCounters - публикуемые счётчики в порядке выдачи ("<Протокол>.<Счётчик>").
+var netProtoCounters = []string{
+	"Tcp.ActiveOpens",
+	"Tcp.PassiveOpens",
+	"Tcp.AttemptFails",
+	"Tcp.EstabResets",
+	"Tcp.InSegs",
+	"Tcp.OutSegs",
+	"Tcp.RetransSegs",
+	"Tcp.InErrs",
+	"Tcp.OutRsts",
+	"Tcp.InCsumErrors",
+	"TcpExt.ListenOverflows",
+	"TcpExt.ListenDrops",
+	"TcpExt.TCPTimeouts",
+	"TcpExt.TCPLostRetransmit",
+	"TcpExt.TCPAbortOnTimeout",
+	"TcpExt.SyncookiesSent",
+	"TcpExt.SyncookiesRecv",
+	"TcpExt.SyncookiesFailed",
+	"TcpExt.TCPBacklogDrop",
+	"Udp.InDatagrams",
+	"Udp.OutDatagrams",
+	"Udp.NoPorts",
+	"Udp.InErrors",
+	"Udp.RcvbufErrors",
+	"Udp.SndbufErrors",
+	"Udp.InCsumErrors",
+}
+
+// netProtoRates - вычисляет скорости счётчиков (в секунду) по истории замеров.
+func netProtoRates(history []model.NetProtoStats) *pb.NetProtoStats {
+	first, last := history[0], history[len(history)-1]
+	elapsed := last.Time.Sub(first.Time).Seconds()
+
+	result := &pb.NetProtoStats{}
+	for _, key := range netProtoCounters {
+		total, ok := last.Counters[key]
+		if !ok {
+			continue // Счётчик не поддерживается ядром
+		}
+
+		values := make([]float64, 0, len(history))
+		for _, h := range history {
+			if v, ok := h.Counters[key]; ok {
+				values = append(values, v)
+			}
+		}
+
+		var rate float64
+		if elapsed > 0 {
+			rate = round(sumIncrements(values) / elapsed)
+		}
+
+		protocol, name, _ := strings.Cut(key, ".")
+		result.Counters = append(result.Counters, &pb.ProtoCounter{
+			Protocol: protocol,
+			Name:     name,
+			Rate:     rate,
+			Total:    total,
+		})
+	}
+
+	return result
+}
+
+// sumIncrements - суммирует приросты счётчика между соседними замерами.
+// Уменьшение значения (сброс счётчика) пропускается.
+func sumIncrements(values []float64) float64 {
+	var delta float64
+	for i := 1; i < len(values); i++ {
+		if values[i] >= values[i-1] {
+			delta += values[i] - values[i-1]
+		}
+	}
+	return delta
+}
+
+// GetNetProtoStats - читает счётчики протоколов из /proc/net/snmp и /proc/net/netstat.
+func GetNetProtoStats(reader FileReader) (model.NetProtoStats, error) {
+	stats := model.NetProtoStats{
+		Time:     time.Now(),
+		Counters: make(map[string]float64),
+	}
+
+	for _, filename := range []string{"/proc/net/snmp", "/proc/net/netstat"} {
+		data, err := reader.ReadFile(filename)
+		if err != nil {
+			return model.NetProtoStats{}, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		if err := parseProcNetCounters(data, stats.Counters); err != nil {
+			return model.NetProtoStats{}, fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+	}
+
+	if len(stats.Counters) == 0 {
+		return model.NetProtoStats{}, fmt.Errorf("no valid protocol counters found")
+	}
+
+	return stats, nil
+}
+
+// parseProcNetCounters - разбирает пары строк "Proto: имена" / "Proto: значения".
+func parseProcNetCounters(data []byte, counters map[string]float64) error {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	for i := 0; i+1 < len(lines); i += 2 {
+		names := strings.Fields(lines[i])
+		values := strings.Fields(lines[i+1])
+		if len(names) == 0 || len(names) != len(values) || names[0] != values[0] {
+			return fmt.Errorf("unexpected format at line %d", i+1)
+		}
+
+		protocol := strings.TrimSuffix(names[0], ":")
+		for j := 1; j < len(names); j++ {
+			value, err := strconv.ParseFloat(values[j], 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s.%s: %w", protocol, names[j], err)
+			}
+			counters[protocol+"."+names[j]] = value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+)
+
+var netProtoFS = MockFS{
+	Files: map[string][]byte{
+		"/proc/net/snmp": []byte(`Ip: Forwarding DefaultTTL
+Ip: 2 64
+Tcp: RtoAlgorithm MaxConn ActiveOpens RetransSegs InErrs
+Tcp: 1 -1 7 120 3
+Udp: InDatagrams RcvbufErrors
+Udp: 8 5
+`),
+		"/proc/net/netstat": []byte(`TcpExt: SyncookiesSent ListenOverflows ListenDrops TCPTimeouts
+TcpExt: 1 2 4 9
+`),
+	},
+}
+
+func TestGetNetProtoStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		reader  FileReader
+		want    map[string]float64
+		wantErr bool
+	}{
+		{
+			name:   "valid data",
+			reader: netProtoFS,
+			want: map[string]float64{
+				"Tcp.RetransSegs":        120,
+				"Tcp.MaxConn":            -1,
+				"Udp.RcvbufErrors":       5,
+				"TcpExt.ListenOverflows": 2,
+				"TcpExt.TCPTimeouts":     9,
+			},
+		},
+		{
+			name: "mismatched header",
+			reader: MockFS{Files: map[string][]byte{
+				"/proc/net/snmp":    []byte("Tcp: A B\nTcp: 1\n"),
+				"/proc/net/netstat": []byte(""),
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "file missing",
+			reader:  MockFS{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := GetNetProtoStats(tt.reader)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetNetProtoStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for key, want := range tt.want {
+				if got := stats.Counters[key]; got != want {
+					t.Errorf("GetNetProtoStats() %s = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNetProtoRates(t *testing.T) {
+	start := time.Now()
+	history := []model.NetProtoStats{
+		{Time: start, Counters: map[string]float64{"Tcp.RetransSegs": 100, "Udp.RcvbufErrors": 50}},
+		{Time: start.Add(5 * time.Second), Counters: map[string]float64{"Tcp.RetransSegs": 120, "Udp.RcvbufErrors": 60}},
+		// Сброс счётчика UDP (например, перезагрузка модуля) не даёт отрицательной скорости
+		{Time: start.Add(10 * time.Second), Counters: map[string]float64{"Tcp.RetransSegs": 150, "Udp.RcvbufErrors": 5}},
+	}
+
+	got := netProtoRates(history)
+	want := map[string]struct{ rate, total float64 }{
+		"RetransSegs":  {5, 150},
+		"RcvbufErrors": {1, 5},
+	}
+	if len(got.Counters) != len(want) {
+		t.Fatalf("netProtoRates() got %d counters, want %d", len(got.Counters), len(want))
+	}
+	for _, c := range got.Counters {
+		w := want[c.Name]
+		if math.Abs(c.Rate-w.rate) > 0.01 || c.Total != w.total {
+			t.Errorf("netProtoRates() %s = %v/%v, want %v/%v", c.Name, c.Rate, c.Total, w.rate, w.total)
+		}
+	}
+}
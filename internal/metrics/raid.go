@@ -0,0 +1,216 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+var (
+	// Состав массива: "[2/1]" - всего дисков / активных.
+	mdDisksRe = regexp.MustCompile(`\[(\d+)/(\d+)\]`)
+	// Прогресс синхронизации: "recovery =  8.5% (...) finish=1.2min speed=12800K/sec".
+	mdSyncRe = regexp.MustCompile(`(resync|recovery|reshape|check|repair)\s*=\s*([\d.]+)%`)
+	// Скорость синхронизации в KB/s.
+	mdSpeedRe = regexp.MustCompile(`speed=(\d+)K/sec`)
+)
+
+// raidArrays - формирует состояние массивов по истории замеров.
+// Состояние берётся из последнего замера, скорость синхронизации усредняется.
+func raidArrays(history []model.RAIDStats) []*pb.RAIDArray {
+	last := history[len(history)-1]
+
+	result := make([]*pb.RAIDArray, 0, len(last.Arrays))
+	for _, array := range last.Arrays {
+		var sumSpeed, count float64
+		for _, h := range history {
+			for _, a := range h.Arrays {
+				if a.Name == array.Name {
+					sumSpeed += a.SyncSpeedKBs
+					count++
+					break
+				}
+			}
+		}
+
+		members := make([]*pb.RAIDMember, 0, len(array.Members))
+		for _, m := range array.Members {
+			members = append(members, &pb.RAIDMember{
+				Device: m.Device,
+				Role:   int32(m.Role), //nolint:gosec
+				State:  m.State,
+			})
+		}
+
+		result = append(result, &pb.RAIDArray{
+			Name:         array.Name,
+			Level:        array.Level,
+			State:        array.State,
+			Health:       raidHealth(array),
+			RaidDisks:    int32(array.RaidDisks),   //nolint:gosec
+			ActiveDisks:  int32(array.ActiveDisks), //nolint:gosec
+			Degraded:     int32(array.Degraded),    //nolint:gosec
+			Members:      members,
+			SyncAction:   array.SyncAction,
+			SyncProgress: round(array.SyncProgress),
+			SyncSpeedKbs: round(sumSpeed / count),
+		})
+	}
+
+	return result
+}
+
+// raidHealth - вычисляет итоговое состояние массива.
+func raidHealth(array model.RAIDArray) pb.RAIDHealth {
+	failed := 0
+	for _, m := range array.Members {
+		if m.State == "faulty" {
+			failed++
+		}
+	}
+
+	switch {
+	case array.State == "inactive" || (array.RaidDisks > 0 && array.ActiveDisks == 0):
+		return pb.RAIDHealth_RAID_HEALTH_FAILED
+	case array.Degraded > 0 || failed > 0:
+		return pb.RAIDHealth_RAID_HEALTH_DEGRADED
+	case array.SyncAction != "" && array.SyncAction != "idle":
+		return pb.RAIDHealth_RAID_HEALTH_RESYNCING
+	default:
+		return pb.RAIDHealth_RAID_HEALTH_OK
+	}
+}
+
+// GetRAIDStats - разбирает /proc/mdstat и дополняет данные из /sys/block/md*/md.
+// Без /proc/mdstat (модуль md не загружен) массивов нет, это не ошибка.
+func GetRAIDStats(reader FileReader) (model.RAIDStats, error) {
+	data, err := reader.ReadFile("/proc/mdstat")
+	if errors.Is(err, os.ErrNotExist) {
+		return model.RAIDStats{}, nil
+	}
+	if err != nil {
+		return model.RAIDStats{}, fmt.Errorf("failed to read /proc/mdstat: %w", err)
+	}
+
+	var stats model.RAIDStats
+	var current *model.RAIDArray
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) >= 3 && strings.HasPrefix(fields[0], "md") && fields[1] == ":":
+			stats.Arrays = append(stats.Arrays, parseMDHeader(fields))
+			current = &stats.Arrays[len(stats.Arrays)-1]
+		case current == nil || len(fields) == 0:
+			continue
+		default:
+			parseMDStatus(line, current)
+		}
+	}
+
+	for i := range stats.Arrays {
+		readMDSysfs(reader, &stats.Arrays[i])
+	}
+
+	return stats, nil
+}
+
+// parseMDHeader - разбирает строку "md0 : active raid1 sdb1[1] sda1[0](F)".
+func parseMDHeader(fields []string) model.RAIDArray {
+	array := model.RAIDArray{Name: fields[0], State: fields[2]}
+
+	rest := fields[3:]
+	// Уровень отсутствует у неактивных массивов; "(auto-read-only)" и т.п. пропускаем
+	for len(rest) > 0 && strings.HasPrefix(rest[0], "(") {
+		rest = rest[1:]
+	}
+	if len(rest) > 0 && !strings.Contains(rest[0], "[") {
+		array.Level = rest[0]
+		rest = rest[1:]
+	}
+
+	for _, dev := range rest {
+		name, tail, ok := strings.Cut(dev, "[")
+		if !ok {
+			continue
+		}
+		roleStr, flags, _ := strings.Cut(tail, "]")
+		role, _ := strconv.Atoi(roleStr)
+
+		state := "in_sync"
+		switch {
+		case strings.Contains(flags, "(F)"):
+			state = "faulty"
+		case strings.Contains(flags, "(S)"):
+			state = "spare"
+		case strings.Contains(flags, "(W)"):
+			state = "write_mostly"
+		}
+		array.Members = append(array.Members, model.RAIDMember{Device: name, Role: role, State: state})
+	}
+
+	return array
+}
+
+// parseMDStatus - разбирает строки состояния массива (состав дисков и прогресс синхронизации).
+func parseMDStatus(line string, array *model.RAIDArray) {
+	if m := mdDisksRe.FindStringSubmatch(line); m != nil {
+		array.RaidDisks, _ = strconv.Atoi(m[1])
+		array.ActiveDisks, _ = strconv.Atoi(m[2])
+		array.Degraded = max(array.RaidDisks-array.ActiveDisks, 0)
+	}
+	if m := mdSyncRe.FindStringSubmatch(line); m != nil {
+		array.SyncAction = m[1]
+		array.SyncProgress, _ = strconv.ParseFloat(m[2], 64)
+	}
+	if m := mdSpeedRe.FindStringSubmatch(line); m != nil {
+		array.SyncSpeedKBs, _ = strconv.ParseFloat(m[1], 64)
+	}
+}
+
+// readMDSysfs - уточняет состояние массива по /sys/block/<md>/md, если он доступен.
+func readMDSysfs(reader FileReader, array *model.RAIDArray) {
+	dir := filepath.Join("/sys/block", array.Name, "md")
+	read := func(name string) (string, bool) {
+		data, err := reader.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return "", false
+		}
+		return strings.TrimSpace(string(data)), true
+	}
+
+	if level, ok := read("level"); ok && level != "" {
+		array.Level = level
+	}
+	if degraded, ok := read("degraded"); ok {
+		if v, err := strconv.Atoi(degraded); err == nil {
+			array.Degraded = v
+		}
+	}
+	if state, ok := read("array_state"); ok && state != "" {
+		array.State = state
+	}
+	if action, ok := read("sync_action"); ok && action != "" {
+		array.SyncAction = action
+	}
+	if speed, ok := read("sync_speed"); ok {
+		if v, err := strconv.ParseFloat(speed, 64); err == nil {
+			array.SyncSpeedKBs = v
+		}
+	}
+	// "done / total" в секторах или "none"
+	if completed, ok := read("sync_completed"); ok {
+		done, total, found := strings.Cut(completed, "/")
+		d, errD := strconv.ParseFloat(strings.TrimSpace(done), 64)
+		t, errT := strconv.ParseFloat(strings.TrimSpace(total), 64)
+		if found && errD == nil && errT == nil && t > 0 {
+			array.SyncProgress = d / t * 100
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/model"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+const mdstat = `Personalities : [raid1] [raid6] [raid5] [raid4]
+md0 : active raid1 sdb1[1] sda1[0]
+      1047552 blocks super 1.2 [2/2] [UU]
+
+md1 : active raid5 sdd1[3](F) sdc1[2] sdb2[1] sda2[0]
+      2095104 blocks super 1.2 level 5, 512k chunk, algorithm 2 [4/3] [UUU_]
+      [=>...................]  recovery =  8.5% (89600/1047552) finish=1.2min speed=12800K/sec
+
+md2 : inactive sde1[0](S)
+      1047552 blocks super 1.2
+
+unused devices: <none>
+`
+
+func TestGetRAIDStats(t *testing.T) {
+	reader := MockFS{Files: map[string][]byte{
+		"/proc/mdstat":                     []byte(mdstat),
+		"/sys/block/md0/md/sync_action":    []byte("check\n"),
+		"/sys/block/md0/md/degraded":       []byte("0\n"),
+		"/sys/block/md0/md/array_state":    []byte("clean\n"),
+		"/sys/block/md0/md/sync_speed":     []byte("5000\n"),
+		"/sys/block/md0/md/sync_completed": []byte("500 / 1000\n"),
+	}}
+
+	stats, err := GetRAIDStats(reader)
+	if err != nil {
+		t.Fatalf("GetRAIDStats() unexpected error: %v", err)
+	}
+	if len(stats.Arrays) != 3 {
+		t.Fatalf("GetRAIDStats() got %d arrays, want 3", len(stats.Arrays))
+	}
+
+	md0 := stats.Arrays[0]
+	if md0.Name != "md0" || md0.Level != "raid1" || md0.State != "clean" || md0.SyncAction != "check" ||
+		md0.SyncProgress != 50 || md0.SyncSpeedKBs != 5000 || len(md0.Members) != 2 {
+		t.Errorf("GetRAIDStats() md0 = %+v", md0)
+	}
+
+	md1 := stats.Arrays[1]
+	wantMembers := []model.RAIDMember{
+		{Device: "sdd1", Role: 3, State: "faulty"},
+		{Device: "sdc1", Role: 2, State: "in_sync"},
+		{Device: "sdb2", Role: 1, State: "in_sync"},
+		{Device: "sda2", Role: 0, State: "in_sync"},
+	}
+	if md1.Level != "raid5" || md1.RaidDisks != 4 || md1.ActiveDisks != 3 || md1.Degraded != 1 ||
+		md1.SyncAction != "recovery" || md1.SyncProgress != 8.5 || md1.SyncSpeedKBs != 12800 {
+		t.Errorf("GetRAIDStats() md1 = %+v", md1)
+	}
+	for i, want := range wantMembers {
+		if md1.Members[i] != want {
+			t.Errorf("GetRAIDStats() md1 member #%d = %+v, want %+v", i, md1.Members[i], want)
+		}
+	}
+
+	md2 := stats.Arrays[2]
+	if md2.State != "inactive" || md2.Level != "" || len(md2.Members) != 1 || md2.Members[0].State != "spare" {
+		t.Errorf("GetRAIDStats() md2 = %+v", md2)
+	}
+
+	// Без модуля md нет и /proc/mdstat: массивов нет, ошибки нет
+	if stats, err := GetRAIDStats(MockFS{}); err != nil || len(stats.Arrays) != 0 {
+		t.Errorf("GetRAIDStats() without /proc/mdstat = %+v, %v, want no arrays", stats, err)
+	}
+}
+
+func TestRAIDHealth(t *testing.T) {
+	tests := []struct {
+		name  string
+		array model.RAIDArray
+		want  pb.RAIDHealth
+	}{
+		{"healthy", model.RAIDArray{State: "clean", RaidDisks: 2, ActiveDisks: 2}, pb.RAIDHealth_RAID_HEALTH_OK},
+		{"idle action", model.RAIDArray{State: "clean", RaidDisks: 2, ActiveDisks: 2, SyncAction: "idle"}, pb.RAIDHealth_RAID_HEALTH_OK},
+		{"check running", model.RAIDArray{State: "active", RaidDisks: 2, ActiveDisks: 2, SyncAction: "check"}, pb.RAIDHealth_RAID_HEALTH_RESYNCING},
+		{"missing disk", model.RAIDArray{State: "active", RaidDisks: 2, ActiveDisks: 1, Degraded: 1, SyncAction: "recovery"}, pb.RAIDHealth_RAID_HEALTH_DEGRADED},
+		{
+			"faulty member",
+			model.RAIDArray{State: "active", RaidDisks: 2, ActiveDisks: 2, Members: []model.RAIDMember{{State: "faulty"}}},
+			pb.RAIDHealth_RAID_HEALTH_DEGRADED,
+		},
+		{"inactive", model.RAIDArray{State: "inactive"}, pb.RAIDHealth_RAID_HEALTH_FAILED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := raidHealth(tt.array); got != tt.want {
+				t.Errorf("raidHealth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRAIDArrays(t *testing.T) {
+	history := []model.RAIDStats{
+		{Arrays: []model.RAIDArray{{Name: "md1", State: "active", RaidDisks: 2, ActiveDisks: 1, Degraded: 1, SyncSpeedKBs: 1000}}},
+		{Arrays: []model.RAIDArray{{Name: "md1", State: "active", RaidDisks: 2, ActiveDisks: 1, Degraded: 1, SyncSpeedKBs: 3000, SyncProgress: 42}}},
+	}
+
+	got := raidArrays(history)
+	if len(got) != 1 {
+		t.Fatalf("raidArrays() got %d arrays, want 1", len(got))
+	}
+	if got[0].Health != pb.RAIDHealth_RAID_HEALTH_DEGRADED || got[0].SyncSpeedKbs != 2000 || got[0].SyncProgress != 42 {
+		t.Errorf("raidArrays() = %+v, want degraded with 2000 KB/s at 42%%", got[0])
+	}
+}
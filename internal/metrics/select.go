@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// Subsystems - имена всех подсистем в порядке вывода.
+var Subsystems = []string{
+	SubsystemLoadAvg,
+	SubsystemCPU,
+	SubsystemDisk,
+	SubsystemFilesystem,
+	SubsystemFD,
+	SubsystemNetProto,
+	SubsystemNetIface,
+	SubsystemRAID,
+	SubsystemBlockDevices,
+	SubsystemDaemon,
+}
+
+// subsystemFlag - возвращает флаг включения подсистемы по её имени, nil для неизвестного имени.
+func subsystemFlag(enabled *config.MetricsConfig, name string) *bool {
+	switch name {
+	case SubsystemLoadAvg:
+		return &enabled.LoadAvg
+	case SubsystemCPU:
+		return &enabled.CPU
+	case SubsystemDisk:
+		return &enabled.Disk
+	case SubsystemFilesystem:
+		return &enabled.Filesystem
+	case SubsystemFD:
+		return &enabled.FD
+	case SubsystemNetProto:
+		return &enabled.NetProto
+	case SubsystemNetIface:
+		return &enabled.NetIface
+	case SubsystemRAID:
+		return &enabled.RAID
+	case SubsystemBlockDevices:
+		return &enabled.BlockDevices
+	case SubsystemDaemon:
+		return &enabled.Daemon
+	default:
+		return nil
+	}
+}
+
+// SelectSubsystems - оставляет включёнными только запрошенные подсистемы.
+// Пустой список означает все включённые в конфигурации подсистемы.
+func SelectSubsystems(enabled config.MetricsConfig, names []string) (config.MetricsConfig, error) {
+	if len(names) == 0 {
+		return enabled, nil
+	}
+
+	var selected config.MetricsConfig
+	for _, name := range names {
+		flag := subsystemFlag(&enabled, name)
+		if flag == nil {
+			return config.MetricsConfig{}, fmt.Errorf("unknown subsystem %q", name)
+		}
+		*subsystemFlag(&selected, name) = *flag
+	}
+
+	return selected, nil
+}
+
+// EnabledSubsystems - имена включённых подсистем в порядке вывода.
+func EnabledSubsystems(enabled config.MetricsConfig) []string {
+	var names []string
+	for _, name := range Subsystems {
+		if *subsystemFlag(&enabled, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// BaseResolution - базовое разрешение опроса подсистемы; 0 - без ограничения.
+func BaseResolution(res config.ResolutionConfig, name string) time.Duration {
+	var ms int
+	switch name {
+	case SubsystemLoadAvg:
+		ms = res.LoadAvg
+	case SubsystemCPU:
+		ms = res.CPU
+	case SubsystemDisk:
+		ms = res.Disk
+	case SubsystemFilesystem:
+		ms = res.Filesystem
+	case SubsystemFD:
+		ms = res.FD
+	case SubsystemNetProto:
+		ms = res.NetProto
+	case SubsystemNetIface:
+		ms = res.NetIface
+	case SubsystemRAID:
+		ms = res.RAID
+	case SubsystemBlockDevices:
+		ms = res.BlockDevices
+	case SubsystemDaemon:
+		ms = res.Daemon
+	}
+	return time.Duration(max(ms, 0)) * time.Millisecond
+}
+
+// FilterStats - применяет к ответу фильтры подсистем из запроса клиента.
+func FilterStats(stats *pb.StatsResponse, opts *pb.SubsystemOptions) {
+	if opts == nil {
+		return
+	}
+
+	if disks := opts.GetDisks(); len(disks) > 0 {
+		stats.DiskStats = slices.DeleteFunc(stats.DiskStats, func(d *pb.DiskStats) bool {
+			return !slices.Contains(disks, d.GetDevice())
+		})
+	}
+	if mountpoints := opts.GetMountpoints(); len(mountpoints) > 0 {
+		stats.FilesystemStats = slices.DeleteFunc(stats.FilesystemStats, func(fs *pb.FilesystemStats) bool {
+			return !slices.Contains(mountpoints, fs.GetMountpoint())
+		})
+	}
+	if interfaces := opts.GetInterfaces(); len(interfaces) > 0 {
+		stats.NetIfaceStats = slices.DeleteFunc(stats.NetIfaceStats, func(i *pb.NetIfaceStats) bool {
+			return !slices.Contains(interfaces, i.GetName())
+		})
+	}
+
+	stats.Aggregates = FilterAggregates(stats.Aggregates, opts)
+	stats.Events = slices.DeleteFunc(stats.Events, func(e *pb.EntityEvent) bool {
+		return !labelSelected(opts, e.GetSubsystem(), e.GetName())
+	})
+
+	// Процессы уже отсортированы по убыванию; превысившие порог остаются в любом случае
+	if topN := int(opts.GetFdTopN()); topN > 0 && stats.GetFdStats() != nil {
+		var kept int
+		stats.FdStats.Processes = slices.DeleteFunc(stats.FdStats.Processes, func(p *pb.ProcessFDStats) bool {
+			if kept < topN || p.GetOverThreshold() {
+				kept++
+				return false
+			}
+			return true
+		})
+	}
+}
+
+// FilterAggregates - применяет к агрегатам фильтры дисков, точек монтирования и интерфейсов.
+func FilterAggregates(aggregates []*pb.Aggregate, opts *pb.SubsystemOptions) []*pb.Aggregate {
+	return slices.DeleteFunc(aggregates, func(a *pb.Aggregate) bool {
+		return !labelSelected(opts, a.GetSubsystem(), a.GetLabel())
+	})
+}
+
+// labelSelected - проходит ли сущность подсистемы фильтры дисков, точек монтирования и интерфейсов.
+func labelSelected(opts *pb.SubsystemOptions, subsystem, label string) bool {
+	var keep []string
+	switch subsystem {
+	case SubsystemDisk:
+		keep = opts.GetDisks()
+	case SubsystemFilesystem:
+		keep = opts.GetMountpoints()
+	case SubsystemNetIface:
+		keep = opts.GetInterfaces()
+	}
+	return len(keep) == 0 || slices.Contains(keep, label)
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+func TestSelectSubsystems(t *testing.T) {
+	enabled := config.MetricsConfig{LoadAvg: true, CPU: true, Disk: true}
+
+	tests := []struct {
+		name    string
+		names   []string
+		want    config.MetricsConfig
+		wantErr bool
+	}{
+		{"all enabled", nil, enabled, false},
+		{"subset", []string{SubsystemCPU}, config.MetricsConfig{CPU: true}, false},
+		{"disabled in config", []string{SubsystemCPU, SubsystemRAID}, config.MetricsConfig{CPU: true}, false},
+		{"unknown", []string{"gpu"}, config.MetricsConfig{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectSubsystems(enabled, tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectSubsystems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SelectSubsystems() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterStats(t *testing.T) {
+	stats := &pb.StatsResponse{
+		DiskStats:       []*pb.DiskStats{{Device: "sda"}, {Device: "sdb"}},
+		FilesystemStats: []*pb.FilesystemStats{{Mountpoint: "/"}, {Mountpoint: "/home"}},
+		NetIfaceStats:   []*pb.NetIfaceStats{{Name: "eth0"}, {Name: "lo"}},
+		FdStats: &pb.FDStats{Processes: []*pb.ProcessFDStats{
+			{Pid: 1, OverThreshold: true},
+			{Pid: 2, OverThreshold: true},
+			{Pid: 3},
+			{Pid: 4},
+		}},
+	}
+
+	FilterStats(stats, &pb.SubsystemOptions{
+		Disks:       []string{"sdb"},
+		Mountpoints: []string{"/"},
+		Interfaces:  []string{"eth0"},
+		FdTopN:      1,
+	})
+
+	if len(stats.DiskStats) != 1 || stats.DiskStats[0].GetDevice() != "sdb" {
+		t.Errorf("FilterStats() disks = %v, want [sdb]", stats.DiskStats)
+	}
+	if len(stats.FilesystemStats) != 1 || stats.FilesystemStats[0].GetMountpoint() != "/" {
+		t.Errorf("FilterStats() filesystems = %v, want [/]", stats.FilesystemStats)
+	}
+	if len(stats.NetIfaceStats) != 1 || stats.NetIfaceStats[0].GetName() != "eth0" {
+		t.Errorf("FilterStats() interfaces = %v, want [eth0]", stats.NetIfaceStats)
+	}
+	// Превысившие порог процессы не отбрасываются
+	if got := stats.GetFdStats().GetProcesses(); len(got) != 2 {
+		t.Errorf("FilterStats() fd processes = %v, want 2 over threshold", got)
+	}
+
+	FilterStats(stats, nil)
+	if len(stats.DiskStats) != 1 {
+		t.Errorf("FilterStats(nil) changed stats")
+	}
+}
@@ -0,0 +1,258 @@
+package metrics
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// latencyBounds - верхние границы корзин гистограмм длительности замеров и команд.
+var latencyBounds = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// SelfStats - внутренние метрики демона: длительность и ошибки замеров коллекторов движка
+// и внешних команд, активные потоки клиентов и их очереди отправки, ресурсы процесса.
+type SelfStats struct {
+	reader  FileReader
+	started time.Time
+
+	mu         sync.Mutex
+	collectors map[string]*latency
+	commands   map[string]*latency
+	streams    map[string]int
+	queues     map[int]func() int // Длины очередей отправки активных потоков
+	nextQueue  int
+	last       *daemonSample // Замер предыдущего запроса Stats
+}
+
+// latency - накопленная гистограмма длительностей.
+type latency struct {
+	counts []uint64 // По корзинам latencyBounds и корзина сверх последней границы
+	count  uint64
+	sum    time.Duration
+	errors uint64
+}
+
+// daemonSample - замер внутренних метрик демона.
+type daemonSample struct {
+	at         time.Time
+	cpu        time.Duration // Время CPU процесса с момента запуска
+	rss        uint64        // Резидентная память, байт
+	goroutines int
+	streams    []*pb.StreamCount
+	queued     int // Сообщений во всех очередях отправки
+	maxQueue   int
+	collectors []*pb.LatencyHistogram
+	commands   []*pb.LatencyHistogram
+}
+
+// NewSelfStats - создаёт внутренние метрики процесса, ресурсы которого читаются через reader.
+func NewSelfStats(reader FileReader) *SelfStats {
+	return &SelfStats{
+		reader:     reader,
+		started:    time.Now(),
+		collectors: make(map[string]*latency),
+		commands:   make(map[string]*latency),
+		streams:    make(map[string]int),
+		queues:     make(map[int]func() int),
+	}
+}
+
+// ObserveCollector - учитывает замер подсистемы общим движком.
+func (s *SelfStats) ObserveCollector(subsystem string, d time.Duration, err error) {
+	s.observe(s.collectors, subsystem, d, err)
+}
+
+// observe - учитывает длительность в гистограмме name.
+func (s *SelfStats) observe(histograms map[string]*latency, name string, d time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := histograms[name]
+	if !ok {
+		h = &latency{counts: make([]uint64, len(latencyBounds)+1)}
+		histograms[name] = h
+	}
+	h.counts[sort.Search(len(latencyBounds), func(i int) bool { return d <= latencyBounds[i] })]++
+	h.count++
+	h.sum += d
+	if err != nil {
+		h.errors++
+	}
+}
+
+// Stream - учитывает активный поток метода rpc с очередью отправки длины depth.
+// Возвращает функцию, которую поток вызывает при завершении.
+func (s *SelfStats) Stream(rpc string, depth func() int) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.streams[rpc]++
+	id := s.nextQueue
+	s.nextQueue++
+	s.queues[id] = depth
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.streams[rpc]--
+		delete(s.queues, id)
+	}
+}
+
+// Commander - выполнение команд через cmd с учётом длительности каждой команды.
+func (s *SelfStats) Commander(cmd Commander) Commander {
+	if cmd == nil {
+		return nil
+	}
+	return observedCommander{cmd: cmd, self: s}
+}
+
+// observedCommander - Commander, учитывающий длительность и ошибки команд по их имени.
+type observedCommander struct {
+	cmd  Commander
+	self *SelfStats
+}
+
+func (c observedCommander) Run(name string, args ...string) ([]byte, error) {
+	return c.RunContext(context.Background(), name, args...)
+}
+
+// RunContext - выполняет команду и учитывает её длительность.
+func (c observedCommander) RunContext(ctx context.Context, name string, args ...string) ([]byte, error) {
+	start := time.Now()
+	output, err := c.cmd.RunContext(ctx, name, args...)
+	c.self.observe(c.self.commands, name, time.Since(start), err)
+	return output, err
+}
+
+// sample - замер внутренних метрик на момент now.
+func (s *SelfStats) sample(now time.Time) (daemonSample, error) {
+	cpu, rss, err := readProcessUsage(s.reader)
+	if err != nil {
+		return daemonSample{}, err
+	}
+
+	sample := daemonSample{at: now, cpu: cpu, rss: rss, goroutines: runtime.NumGoroutine()}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, depth := range s.queues {
+		n := depth()
+		sample.queued += n
+		sample.maxQueue = max(sample.maxQueue, n)
+	}
+	for rpc, active := range s.streams {
+		if active > 0 {
+			sample.streams = append(sample.streams, &pb.StreamCount{Rpc: rpc, Active: int32(active)}) //nolint:gosec
+		}
+	}
+	sort.Slice(sample.streams, func(i, j int) bool { return sample.streams[i].GetRpc() < sample.streams[j].GetRpc() })
+	sample.collectors = histograms(s.collectors)
+	sample.commands = histograms(s.commands)
+
+	return sample, nil
+}
+
+// histograms - гистограммы в порядке имён.
+func histograms(from map[string]*latency) []*pb.LatencyHistogram {
+	bounds := make([]float64, 0, len(latencyBounds))
+	for _, b := range latencyBounds {
+		bounds = append(bounds, ms(b))
+	}
+
+	result := make([]*pb.LatencyHistogram, 0, len(from))
+	for name, h := range from {
+		result = append(result, &pb.LatencyHistogram{
+			Name:     name,
+			BoundsMs: bounds,
+			Counts:   append([]uint64(nil), h.counts...),
+			Count:    h.count,
+			SumMs:    round(ms(h.sum)),
+			Errors:   h.errors,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].GetName() < result[j].GetName() })
+	return result
+}
+
+// ms - длительность в миллисекундах.
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// Stats - внутренние метрики на момент now для служебного интерфейса. Загрузка CPU считается
+// с предыдущего вызова, а при первом - с запуска демона.
+func (s *SelfStats) Stats(now time.Time) (*pb.DaemonStats, error) {
+	sample, err := s.sample(now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	prev := s.last
+	s.last = &sample
+	s.mu.Unlock()
+
+	stats := daemonStats([]daemonSample{sample})
+	from := daemonSample{at: s.started}
+	if prev != nil {
+		from = *prev
+	}
+	stats.CpuPercent = cpuPercent(from, sample)
+	return stats, nil
+}
+
+// daemonStats - сворачивает замеры периода: загрузка CPU - по разности крайних замеров,
+// память, горутины и очереди - средние без опорного замера, потоки и гистограммы - по последнему.
+func daemonStats(window []daemonSample) *pb.DaemonStats {
+	first, last := window[0], window[len(window)-1]
+
+	var rss, goroutines, queued float64
+	var maxQueue int
+	values := window
+	if len(window) > 1 {
+		values = window[1:]
+	}
+	for _, sample := range values {
+		rss += float64(sample.rss)
+		goroutines += float64(sample.goroutines)
+		queued += float64(sample.queued)
+		maxQueue = max(maxQueue, sample.maxQueue)
+	}
+	count := float64(len(values))
+
+	stats := &pb.DaemonStats{
+		RssMb:         round(rss / count / (1 << 20)),
+		Goroutines:    round(goroutines / count),
+		Streams:       last.streams,
+		QueueDepth:    round(queued / count),
+		MaxQueueDepth: int32(maxQueue), //nolint:gosec
+		Collectors:    last.collectors,
+		Commands:      last.commands,
+	}
+	stats.CpuPercent = cpuPercent(first, last)
+	return stats
+}
+
+// cpuPercent - загрузка CPU процессом между замерами, % одного ядра.
+func cpuPercent(from, to daemonSample) float64 {
+	elapsed := to.at.Sub(from.at)
+	if elapsed <= 0 {
+		return 0
+	}
+	return round(float64(to.cpu-from.cpu) / float64(elapsed) * 100)
+}
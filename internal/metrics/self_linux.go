@@ -0,0 +1,44 @@
+//go:build linux
+
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// userHZ - частота тиков, в которых ядро отдаёт время CPU процесса (USER_HZ).
+const userHZ = 100
+
+// readProcessUsage - время CPU и резидентная память демона из /proc/self/stat:
+// utime и stime в тиках, rss в страницах. Имя процесса в скобках может содержать пробелы,
+// поэтому поля считаются после последней закрывающей скобки.
+func readProcessUsage(reader FileReader) (time.Duration, uint64, error) {
+	data, err := reader.ReadFile("/proc/self/stat")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read /proc/self/stat: %w", err)
+	}
+
+	i := strings.LastIndexByte(string(data), ')')
+	if i < 0 {
+		return 0, 0, fmt.Errorf("invalid /proc/self/stat format")
+	}
+	// Поля после имени: state(3) ... utime(14) stime(15) ... rss(24)
+	fields := strings.Fields(string(data[i+1:]))
+	if len(fields) < 22 {
+		return 0, 0, fmt.Errorf("invalid /proc/self/stat format: %d fields", len(fields)+2)
+	}
+
+	var values [3]uint64
+	for j, n := range []int{11, 12, 21} {
+		if values[j], err = strconv.ParseUint(fields[n], 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("failed to parse /proc/self/stat field %d: %w", n+3, err)
+		}
+	}
+
+	cpu := time.Duration(values[0]+values[1]) * time.Second / userHZ //nolint:gosec
+	return cpu, values[2] * uint64(os.Getpagesize()), nil            //nolint:gosec
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReadProcessUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		stat    string
+		wantCPU time.Duration
+		wantRSS uint64
+		wantErr bool
+	}{
+		{
+			// Имя процесса с пробелом и скобкой не сдвигает поля
+			name: "comm with spaces",
+			stat: "1234 (monitor (daemon)) S 1 1234 1234 0 -1 4194560 2000 0 0 0 " +
+				"150 50 0 0 20 0 12 0 100 123456789 2500 18446744073709551615",
+			wantCPU: 2 * time.Second,
+			wantRSS: 2500 * uint64(os.Getpagesize()),
+		},
+		{name: "truncated", stat: "1234 (daemon) S 1 1234", wantErr: true},
+		{name: "no comm", stat: "1234 daemon S", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := MockFS{Files: map[string][]byte{"/proc/self/stat": []byte(tt.stat)}}
+			cpu, rss, err := readProcessUsage(reader)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readProcessUsage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cpu != tt.wantCPU || rss != tt.wantRSS {
+				t.Errorf("readProcessUsage() = %v, %d, want %v, %d", cpu, rss, tt.wantCPU, tt.wantRSS)
+			}
+		})
+	}
+}
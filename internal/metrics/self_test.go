@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+func TestSelfStatsObserve(t *testing.T) {
+	self := NewSelfStats(MockFS{})
+	self.ObserveCollector(SubsystemCPU, 500*time.Microsecond, nil)
+	self.ObserveCollector(SubsystemCPU, 50*time.Millisecond, nil)
+	self.ObserveCollector(SubsystemCPU, 10*time.Second, errors.New("read /proc/stat: timed out"))
+
+	// Команды учитываются по имени вместе с ошибками
+	cmd := self.Commander(&MockCommander{Outputs: [][]byte{[]byte("ok")}})
+	if _, err := cmd.Run("df", "-Pk"); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	collectors := histograms(self.collectors)
+	if len(collectors) != 1 || collectors[0].GetName() != SubsystemCPU {
+		t.Fatalf("collectors = %v, want cpu", collectors)
+	}
+	h := collectors[0]
+	want := []uint64{1, 0, 0, 1, 0, 0, 0, 0, 1} // <=1ms, <=50ms, сверх 5s
+	for i := range want {
+		if h.GetCounts()[i] != want[i] {
+			t.Fatalf("counts = %v, want %v", h.GetCounts(), want)
+		}
+	}
+	if h.GetCount() != 3 || h.GetErrors() != 1 || h.GetSumMs() != 10050.5 {
+		t.Errorf("histogram = %v, want count 3, errors 1, sum 10050.5ms", h)
+	}
+
+	commands := histograms(self.commands)
+	if len(commands) != 1 || commands[0].GetName() != "df" || commands[0].GetCount() != 1 {
+		t.Errorf("commands = %v, want one df call", commands)
+	}
+}
+
+func TestSelfStatsStream(t *testing.T) {
+	self := NewSelfStats(MockFS{})
+	depth := 3
+	done := self.Stream("GetStats", func() int { return depth })
+	self.Stream("Watch", func() int { return 1 })
+
+	self.mu.Lock()
+	queued, streams := 0, self.streams["GetStats"]
+	for _, d := range self.queues {
+		queued += d()
+	}
+	self.mu.Unlock()
+	if streams != 1 || queued != 4 {
+		t.Errorf("streams = %d, queued = %d, want 1 and 4", streams, queued)
+	}
+
+	done()
+	if self.streams["GetStats"] != 0 || len(self.queues) != 1 {
+		t.Errorf("after done: streams = %d, queues = %d, want 0 and 1", self.streams["GetStats"], len(self.queues))
+	}
+}
+
+func TestDaemonStats(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	window := []daemonSample{
+		// Опорный замер: только время CPU
+		{at: start, cpu: time.Second, rss: 100 << 20, goroutines: 100},
+		{at: start.Add(time.Second), cpu: 1250 * time.Millisecond, rss: 10 << 20, goroutines: 10, queued: 2, maxQueue: 2},
+		{
+			at: start.Add(2 * time.Second), cpu: 1500 * time.Millisecond, rss: 20 << 20, goroutines: 20, maxQueue: 5,
+			streams: []*pb.StreamCount{{Rpc: "GetStats", Active: 2}},
+		},
+	}
+
+	got := daemonStats(window)
+	if got.GetCpuPercent() != 25 {
+		t.Errorf("cpu_percent = %v, want 25", got.GetCpuPercent())
+	}
+	if got.GetRssMb() != 15 || got.GetGoroutines() != 15 || got.GetQueueDepth() != 1 || got.GetMaxQueueDepth() != 5 {
+		t.Errorf("daemonStats() = %v, want rss 15MB, 15 goroutines, queue 1, max queue 5", got)
+	}
+	if len(got.GetStreams()) != 1 || got.GetStreams()[0].GetActive() != 2 {
+		t.Errorf("streams = %v, want GetStats=2 from the last sample", got.GetStreams())
+	}
+}
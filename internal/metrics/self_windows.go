@@ -0,0 +1,57 @@
+//go:build windows
+
+package metrics
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var procGetProcessMemoryInfo = syscall.NewLazyDLL("kernel32.dll").NewProc("K32GetProcessMemoryInfo")
+
+// processMemoryCounters - структура PROCESS_MEMORY_COUNTERS.
+type processMemoryCounters struct {
+	cb                         uint32
+	pageFaultCount             uint32
+	peakWorkingSetSize         uintptr
+	workingSetSize             uintptr
+	quotaPeakPagedPoolUsage    uintptr
+	quotaPagedPoolUsage        uintptr
+	quotaPeakNonPagedPoolUsage uintptr
+	quotaNonPagedPoolUsage     uintptr
+	pagefileUsage              uintptr
+	peakPagefileUsage          uintptr
+}
+
+// readProcessUsage - время CPU демона через GetProcessTimes (в единицах по 100 нс)
+// и рабочий набор страниц через GetProcessMemoryInfo.
+func readProcessUsage(FileReader) (time.Duration, uint64, error) {
+	process, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0, 0, fmt.Errorf("GetCurrentProcess failed: %w", err)
+	}
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(process, &creation, &exit, &kernel, &user); err != nil {
+		return 0, 0, fmt.Errorf("GetProcessTimes failed: %w", err)
+	}
+
+	counters := processMemoryCounters{}
+	counters.cb = uint32(unsafe.Sizeof(counters))
+	ok, _, err := procGetProcessMemoryInfo.Call(
+		uintptr(process),
+		uintptr(unsafe.Pointer(&counters)),
+		uintptr(counters.cb),
+	)
+	if ok == 0 {
+		return 0, 0, fmt.Errorf("GetProcessMemoryInfo failed: %w", err)
+	}
+
+	ticks := func(ft syscall.Filetime) uint64 {
+		return uint64(ft.HighDateTime)<<32 | uint64(ft.LowDateTime)
+	}
+	cpu := time.Duration(ticks(kernel)+ticks(user)) * 100 //nolint:gosec
+	return cpu, uint64(counters.workingSetSize), nil
+}
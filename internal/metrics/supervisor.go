@@ -0,0 +1,152 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Supervisor - запускает коллекторы с восстановлением после паники: упавший коллектор
+// перезапускается с экспоненциальной задержкой, остальные подсистемы продолжают работу.
+// Перезапуски учитываются по подсистемам.
+type Supervisor struct {
+	log            *logger.Logger
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	stableAfter    time.Duration // Работа без паники, после которой задержка сбрасывается
+
+	mu     sync.Mutex
+	health map[string]*subsystemHealth
+}
+
+// subsystemHealth - перезапуски коллекторов подсистемы.
+type subsystemHealth struct {
+	restarts    int
+	lastPanic   string
+	lastPanicAt time.Time
+	healthyAt   time.Time // До этого момента подсистема считается деградированной
+}
+
+// NewSupervisor - создаёт супервизор коллекторов.
+func NewSupervisor(cfg config.SupervisorConfig, log *logger.Logger) *Supervisor {
+	initial := time.Duration(cfg.InitialBackoff) * time.Second
+	if initial <= 0 {
+		initial = time.Second
+	}
+	return &Supervisor{
+		log:            log,
+		initialBackoff: initial,
+		maxBackoff:     max(time.Duration(cfg.MaxBackoff)*time.Second, initial),
+		stableAfter:    max(time.Duration(cfg.StableAfter)*time.Second, 0),
+		health:         make(map[string]*subsystemHealth),
+	}
+}
+
+// Run - выполняет коллектор подсистемы под наблюдением, пока он не завершится сам
+// или не будет отменён контекст.
+func (s *Supervisor) Run(ctx context.Context, subsystem string, run func(ctx context.Context)) {
+	s.supervise(ctx, subsystem, run)
+}
+
+func (s *Supervisor) supervise(ctx context.Context, subsystem string, run func(ctx context.Context)) {
+	backoff := s.initialBackoff
+	for {
+		started := time.Now()
+		value, stack, panicked := protect(ctx, run)
+		if !panicked {
+			return
+		}
+
+		now := time.Now()
+		if now.Sub(started) >= s.stableAfter {
+			backoff = s.initialBackoff
+		}
+		restarts := s.record(subsystem, fmt.Sprint(value), now, backoff)
+		s.log.Error(fmt.Sprintf("Collector %s panicked: %v, restart #%d in %s\n%s",
+			subsystem, value, restarts, backoff, stack))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, s.maxBackoff)
+	}
+}
+
+// protect - выполняет коллектор, перехватывая панику.
+func protect(ctx context.Context, run func(ctx context.Context)) (value any, stack []byte, panicked bool) {
+	defer func() {
+		if value = recover(); value != nil {
+			stack = debug.Stack()
+			panicked = true
+		}
+	}()
+	run(ctx)
+	return nil, nil, false
+}
+
+// record - учитывает панику коллектора; возвращает номер перезапуска подсистемы.
+func (s *Supervisor) record(subsystem, value string, at time.Time, backoff time.Duration) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.health[subsystem]
+	if !ok {
+		h = &subsystemHealth{}
+		s.health[subsystem] = h
+	}
+	h.restarts++
+	h.lastPanic = value
+	h.lastPanicAt = at
+	if healthyAt := at.Add(backoff + s.stableAfter); healthyAt.After(h.healthyAt) {
+		h.healthyAt = healthyAt
+	}
+	return h.restarts
+}
+
+// Annotate - дополняет покрытие подсистем в meta перезапусками коллекторов. Деградированные
+// подсистемы из enabled, которых нет в meta, добавляются отдельными записями без замеров.
+func (s *Supervisor) Annotate(meta *pb.SnapshotMeta, enabled config.MetricsConfig, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.health) == 0 {
+		return
+	}
+
+	present := make(map[string]bool, len(meta.GetSubsystems()))
+	for _, c := range meta.GetSubsystems() {
+		present[c.GetSubsystem()] = true
+		s.fill(c, now)
+	}
+	for _, subsystem := range EnabledSubsystems(enabled) {
+		h, ok := s.health[subsystem]
+		if present[subsystem] || !ok || !now.Before(h.healthyAt) {
+			continue
+		}
+		c := &pb.SubsystemCoverage{Subsystem: subsystem}
+		s.fill(c, now)
+		meta.Subsystems = append(meta.Subsystems, c)
+	}
+}
+
+// fill - переносит перезапуски коллекторов подсистемы в её покрытие.
+func (s *Supervisor) fill(c *pb.SubsystemCoverage, now time.Time) {
+	h, ok := s.health[c.GetSubsystem()]
+	if !ok {
+		return
+	}
+	c.Restarts = int32(h.restarts) //nolint:gosec
+	c.Degraded = now.Before(h.healthyAt)
+	c.LastPanic = h.lastPanic
+	c.LastPanicTime = timestamppb.New(h.lastPanicAt)
+}
@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+func newTestSupervisor(t *testing.T) *Supervisor {
+	t.Helper()
+	cfg := config.NewConfig()
+	log, _ := logger.New(cfg.Logger)
+	sup := NewSupervisor(cfg.Supervisor, log)
+	sup.initialBackoff = time.Millisecond
+	sup.maxBackoff = 4 * time.Millisecond
+	return sup
+}
+
+func TestSupervisorRestart(t *testing.T) {
+	sup := newTestSupervisor(t)
+
+	// Коллектор дважды падает на неожиданных данных, третий запуск работает до отмены
+	runs := make(chan int, 3)
+	calls := 0
+	go sup.Run(t.Context(), SubsystemCPU, func(ctx context.Context) {
+		calls++
+		runs <- calls
+		if calls <= 2 {
+			var fields []string
+			_ = fields[calls] // index out of range
+		}
+		<-ctx.Done()
+	})
+
+	for want := 1; want <= 3; want++ {
+		select {
+		case got := <-runs:
+			if got != want {
+				t.Fatalf("run = %d, want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("collector was not restarted after panic #%d", want-1)
+		}
+	}
+
+	meta := &pb.SnapshotMeta{Subsystems: []*pb.SubsystemCoverage{{Subsystem: SubsystemCPU, Samples: 1}}}
+	sup.Annotate(meta, config.MetricsConfig{CPU: true}, time.Now())
+	c := meta.GetSubsystems()[0]
+	if c.GetRestarts() != 2 || !c.GetDegraded() || c.GetLastPanic() == "" || c.GetLastPanicTime() == nil {
+		t.Errorf("Annotate() coverage = %v, want 2 restarts, degraded with last panic", c)
+	}
+}
+
+func TestSupervisorRunReturns(t *testing.T) {
+	sup := newTestSupervisor(t)
+
+	// Коллектор, завершившийся без паники, не перезапускается
+	calls := 0
+	sup.Run(t.Context(), SubsystemLoadAvg, func(context.Context) { calls++ })
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	// После отмены контекста упавший коллектор не перезапускается
+	ctx, cancel := context.WithCancel(t.Context())
+	calls = 0
+	sup.maxBackoff = time.Hour
+	sup.initialBackoff = time.Hour
+	done := make(chan struct{})
+	go func() {
+		sup.Run(ctx, SubsystemLoadAvg, func(context.Context) {
+			calls++
+			panic("unexpected output")
+		})
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not return after context cancel")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestSupervisorAnnotate(t *testing.T) {
+	sup := newTestSupervisor(t)
+	now := time.Now()
+	sup.record(SubsystemDisk, "boom", now.Add(-2*time.Minute), time.Second)
+	sup.record(SubsystemCPU, "boom", now, time.Second)
+
+	tests := []struct {
+		name    string
+		meta    *pb.SnapshotMeta
+		enabled config.MetricsConfig
+		want    map[string]bool // Подсистема -> degraded
+	}{
+		{
+			name:    "degraded subsystem without data is added",
+			meta:    &pb.SnapshotMeta{Subsystems: []*pb.SubsystemCoverage{{Subsystem: SubsystemLoadAvg}}},
+			enabled: config.MetricsConfig{LoadAvg: true, CPU: true},
+			want:    map[string]bool{SubsystemLoadAvg: false, SubsystemCPU: true},
+		},
+		{
+			name:    "recovered subsystem keeps restarts, not degraded",
+			meta:    &pb.SnapshotMeta{Subsystems: []*pb.SubsystemCoverage{{Subsystem: SubsystemDisk}}},
+			enabled: config.MetricsConfig{Disk: true},
+			want:    map[string]bool{SubsystemDisk: false},
+		},
+		{
+			name:    "not requested subsystem is not added",
+			meta:    &pb.SnapshotMeta{},
+			enabled: config.MetricsConfig{Disk: true},
+			want:    map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sup.Annotate(tt.meta, tt.enabled, now)
+			if len(tt.meta.GetSubsystems()) != len(tt.want) {
+				t.Fatalf("Annotate() subsystems = %v, want %v", tt.meta.GetSubsystems(), tt.want)
+			}
+			for _, c := range tt.meta.GetSubsystems() {
+				degraded, ok := tt.want[c.GetSubsystem()]
+				if !ok || c.GetDegraded() != degraded {
+					t.Errorf("Annotate() %s degraded = %v, want %v", c.GetSubsystem(), c.GetDegraded(), degraded)
+				}
+				if c.GetSubsystem() == SubsystemDisk && c.GetRestarts() != 1 {
+					t.Errorf("Annotate() disk restarts = %d, want 1", c.GetRestarts())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"time"
+
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewSubsystemUpdate - упаковывает данные подсистемы из общего ответа в отдельное обновление.
+func NewSubsystemUpdate(subsystem string, stats *pb.StatsResponse, at time.Time) *pb.SubsystemUpdate {
+	update := &pb.SubsystemUpdate{
+		Time:      timestamppb.New(at),
+		Subsystem: subsystem,
+		Meta:      stats.GetMeta(),
+		Events:    stats.GetEvents(),
+	}
+
+	switch subsystem {
+	case SubsystemLoadAvg:
+		update.Payload = &pb.SubsystemUpdate_LoadAverage{LoadAverage: &pb.LoadAverage{
+			Load_1Min:  stats.GetLoadAverage_1Min(),
+			Load_5Min:  stats.GetLoadAverage_5Min(),
+			Load_15Min: stats.GetLoadAverage_15Min(),
+		}}
+	case SubsystemCPU:
+		update.Payload = &pb.SubsystemUpdate_Cpu{Cpu: &pb.CPUUsage{
+			User:   stats.GetCpuUser(),
+			System: stats.GetCpuSystem(),
+			Idle:   stats.GetCpuIdle(),
+		}}
+	case SubsystemDisk:
+		update.Payload = &pb.SubsystemUpdate_Disk{Disk: &pb.DiskStatsList{Disks: stats.GetDiskStats()}}
+	case SubsystemFilesystem:
+		update.Payload = &pb.SubsystemUpdate_Filesystem{
+			Filesystem: &pb.FilesystemStatsList{Filesystems: stats.GetFilesystemStats()},
+		}
+	case SubsystemFD:
+		update.Payload = &pb.SubsystemUpdate_Fd{Fd: stats.GetFdStats()}
+	case SubsystemNetProto:
+		update.Payload = &pb.SubsystemUpdate_NetProto{NetProto: stats.GetNetProtoStats()}
+	case SubsystemNetIface:
+		update.Payload = &pb.SubsystemUpdate_NetIface{
+			NetIface: &pb.NetIfaceStatsList{Interfaces: stats.GetNetIfaceStats()},
+		}
+	case SubsystemRAID:
+		update.Payload = &pb.SubsystemUpdate_Raid{Raid: &pb.RAIDArrayList{Arrays: stats.GetRaidArrays()}}
+	case SubsystemBlockDevices:
+		update.Payload = &pb.SubsystemUpdate_BlockDevices{
+			BlockDevices: &pb.BlockDeviceList{Devices: stats.GetBlockDevices()},
+		}
+	case SubsystemDaemon:
+		update.Payload = &pb.SubsystemUpdate_Daemon{Daemon: stats.GetDaemonStats()}
+	}
+
+	return update
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+func TestNewSubsystemUpdate(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := &pb.StatsResponse{
+		CpuIdle:   100,
+		DiskStats: []*pb.DiskStats{{Device: "sda"}},
+	}
+
+	// Нулевой load average - это данные, а не их отсутствие
+	load := NewSubsystemUpdate(SubsystemLoadAvg, stats, at)
+	if load.GetLoadAverage() == nil || load.GetLoadAverage().GetLoad_1Min() != 0 {
+		t.Errorf("NewSubsystemUpdate(load_avg) = %v, want zero load average present", load)
+	}
+	if load.GetCpu() != nil {
+		t.Errorf("NewSubsystemUpdate(load_avg) carries cpu payload")
+	}
+	if !load.GetTime().AsTime().Equal(at) || load.GetSubsystem() != SubsystemLoadAvg {
+		t.Errorf("NewSubsystemUpdate(load_avg) time/subsystem = %v/%s", load.GetTime().AsTime(), load.GetSubsystem())
+	}
+
+	if cpu := NewSubsystemUpdate(SubsystemCPU, stats, at); cpu.GetCpu().GetIdle() != 100 {
+		t.Errorf("NewSubsystemUpdate(cpu) = %v, want idle 100", cpu)
+	}
+	if disk := NewSubsystemUpdate(SubsystemDisk, stats, at); len(disk.GetDisk().GetDisks()) != 1 {
+		t.Errorf("NewSubsystemUpdate(disk) = %v, want 1 disk", disk)
+	}
+}
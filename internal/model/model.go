@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // LoadAvgRecord - структура для хранения одного замера load average.
 type LoadAvgRecord struct {
 	Load1min  float64 // Нагрузка за 1 минуту
@@ -14,11 +16,19 @@ type CPUStats struct {
 	Idle   float64 // Процент времени в idle mode
 }
 
-// DiskStats - структура для хранения статистики дисков.
-type DiskStats struct {
-	Device string  // Имя устройства (например, "sda")
-	Tps    float64 // Транзакции в секунду
-	KBs    float64 // Килобайты в секунду (чтение + запись)
+// DiskIOStats - замер накопительных счётчиков ввода-вывода дисков (/proc/diskstats).
+type DiskIOStats struct {
+	Time    time.Time // Время замера
+	Devices []DiskIO  // Целые устройства с ненулевыми счётчиками
+}
+
+// DiskIO - счётчики одного устройства с момента его подключения.
+type DiskIO struct {
+	Device         string  // Имя устройства (например, "sda")
+	Reads          float64 // Завершённых операций чтения
+	Writes         float64 // Завершённых операций записи
+	SectorsRead    float64 // Прочитано секторов по 512 байт
+	SectorsWritten float64 // Записано секторов по 512 байт
 }
 
 // FilesystemStats - структура для хранения статистики файловых систем.
@@ -30,3 +40,86 @@ type FilesystemStats struct {
 	InodesUsed    float64 // Использовано инодов
 	InodesPercent float64 // Процент использованных инодов
 }
+
+// FDStats - структура для хранения статистики файловых дескрипторов.
+type FDStats struct {
+	Allocated float64          // Выделено дескрипторов в системе (/proc/sys/fs/file-nr)
+	Max       float64          // Системный лимит дескрипторов (file-max)
+	Processes []ProcessFDStats // Статистика по процессам
+}
+
+// ProcessFDStats - структура для хранения статистики дескрипторов процесса.
+type ProcessFDStats struct {
+	PID     int     // Идентификатор процесса
+	Command string  // Имя процесса (/proc/[pid]/comm)
+	OpenFDs float64 // Количество открытых дескрипторов
+	Limit   float64 // Мягкий лимит RLIMIT_NOFILE (0 = unlimited)
+}
+
+// NetProtoStats - замер счётчиков сетевых протоколов (/proc/net/snmp, /proc/net/netstat).
+type NetProtoStats struct {
+	Time     time.Time          // Время замера
+	Counters map[string]float64 // Ключ "<Протокол>.<Счётчик>", например "Tcp.RetransSegs"
+}
+
+// NetIfaceStats - замер счётчиков и метаданных сетевых интерфейсов.
+type NetIfaceStats struct {
+	Time       time.Time  // Время замера
+	Interfaces []NetIface // Интерфейсы из /proc/net/dev
+}
+
+// NetIface - счётчики и метаданные линка одного интерфейса.
+type NetIface struct {
+	Name           string  // Имя интерфейса (например, "eth0")
+	RxBytes        float64 // Принято байт (счётчик)
+	TxBytes        float64 // Передано байт (счётчик)
+	SpeedMbps      float64 // Согласованная скорость, Мбит/с (0 = неизвестна)
+	Duplex         string  // full, half, unknown
+	MTU            float64 // MTU
+	OperState      string  // up, down, unknown...
+	CarrierChanges float64 // Количество смен состояния carrier (счётчик)
+}
+
+// RAIDStats - замер состояния программных RAID-массивов (md).
+type RAIDStats struct {
+	Arrays []RAIDArray // Массивы из /proc/mdstat
+}
+
+// RAIDArray - состояние одного md-массива.
+type RAIDArray struct {
+	Name         string       // Имя массива (например, "md0")
+	Level        string       // Уровень RAID (raid1, raid5...)
+	State        string       // Состояние массива (active, inactive, clean...)
+	RaidDisks    int          // Количество дисков в массиве
+	ActiveDisks  int          // Количество рабочих дисков
+	Degraded     int          // Количество отсутствующих дисков
+	Members      []RAIDMember // Устройства массива
+	SyncAction   string       // Текущая операция (resync, recovery, check, reshape, idle)
+	SyncProgress float64      // Прогресс операции, %
+	SyncSpeedKBs float64      // Скорость операции, KB/s
+}
+
+// RAIDMember - устройство в составе md-массива.
+type RAIDMember struct {
+	Device string // Имя устройства (например, "sda1")
+	Role   int    // Номер слота в массиве
+	State  string // in_sync, faulty, spare, write_mostly
+}
+
+// BlockDevice - блочное устройство (диск, раздел, dm/md) из /sys/block.
+type BlockDevice struct {
+	Name        string   // Имя устройства (например, "sda", "sda1", "dm-0")
+	Dev         string   // major:minor
+	Type        string   // disk, part, dm, md, loop
+	Parent      string   // Диск раздела
+	SizeBytes   float64  // Размер, байт
+	Rotational  bool     // Вращающийся носитель (HDD)
+	Model       string   // Модель устройства
+	Scheduler   string   // Активный планировщик ввода-вывода
+	DMName      string   // Имя device-mapper (например, "vg-root")
+	Partitions  []string // Разделы диска
+	Holders     []string // Устройства, построенные поверх (dm, md)
+	Slaves      []string // Устройства, на которых построено dm/md
+	MountedAt   []string // Точки монтирования самого устройства
+	Mountpoints []string // Все обслуживаемые точки монтирования (с учётом разделов и holders)
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/metrics"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminServer - реализует служебный интерфейс AdminServer.
+type adminServer struct {
+	pb.UnimplementedAdminServer
+	engine *metrics.Engine
+}
+
+// GetDaemonStats - внутренние метрики демона на текущий момент. Не зависит от того,
+// включена ли подсистема daemon: гистограммы и потоки учитываются всегда.
+func (s *adminServer) GetDaemonStats(context.Context, *pb.DaemonStatsRequest) (*pb.DaemonStats, error) {
+	stats, err := s.engine.Self().Stats(time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read daemon stats: %v", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/metrics"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// nextBoundary - ближайшая после now граница времени, кратная interval. Границы отсчитываются
+// от нулевого времени, поэтому при N, кратном минуте или её делителю, попадают на :00, :05, :10.
+func nextBoundary(now time.Time, interval time.Duration) time.Time {
+	return now.Truncate(interval).Add(interval)
+}
+
+// feed - общий поток выровненных снимков: на каждой границе, кратной interval, снимок
+// вычисляется один раз и рассылается всем подписчикам с одинаковыми параметрами.
+type feed[T any] struct {
+	interval time.Duration
+	compute  func(at time.Time) T
+	cancel   context.CancelFunc
+
+	mu   sync.Mutex
+	subs map[chan T]struct{}
+}
+
+// run - вычисляет снимок на каждой границе и рассылает его подписчикам. Подписчик, не успевший
+// забрать предыдущий снимок, пропускает текущий: общий поток не ждёт ни одного клиента.
+func (f *feed[T]) run(ctx context.Context) {
+	for {
+		at := nextBoundary(time.Now(), f.interval)
+		timer := time.NewTimer(time.Until(at))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		value := f.compute(at)
+		f.mu.Lock()
+		for ch := range f.subs {
+			select {
+			case ch <- value:
+			default:
+			}
+		}
+		f.mu.Unlock()
+	}
+}
+
+// feedSet - общие потоки по ключу параметров запроса. Поток запускается первым подписчиком
+// и останавливается, когда отписывается последний. Нулевое значение готово к работе.
+type feedSet[T any] struct {
+	mu    sync.Mutex
+	feeds map[string]*feed[T]
+}
+
+// subscribe - подписывает клиента на общий поток с ключом key, запуская его при необходимости.
+// Полученные снимки общие для всех подписчиков и не должны изменяться.
+func (fs *feedSet[T]) subscribe(key string,
+	interval time.Duration,
+	compute func(at time.Time) T,
+) (<-chan T, func()) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	f, ok := fs.feeds[key]
+	if !ok {
+		if fs.feeds == nil {
+			fs.feeds = make(map[string]*feed[T])
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		f = &feed[T]{interval: interval, compute: compute, cancel: cancel, subs: make(map[chan T]struct{})}
+		fs.feeds[key] = f
+		go f.run(ctx)
+	}
+
+	ch := make(chan T, 1)
+	f.mu.Lock()
+	f.subs[ch] = struct{}{}
+	f.mu.Unlock()
+
+	unsubscribe := func() {
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		f.mu.Lock()
+		delete(f.subs, ch)
+		empty := len(f.subs) == 0
+		f.mu.Unlock()
+		if empty && fs.feeds[key] == f {
+			f.cancel()
+			delete(fs.feeds, key)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// len - число запущенных общих потоков.
+func (fs *feedSet[T]) len() int {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return len(fs.feeds)
+}
+
+// feedKey - ключ общего потока: запросы с одинаковыми N, M, подсистемами, усреднением,
+// фильтрами и агрегатами получают один и тот же снимок.
+func feedKey(q engineQuery, interval time.Duration) string {
+	key := &pb.StatsRequest{
+		IntervalMs:   int32(interval.Milliseconds()), //nolint:gosec
+		DurationMs:   int32(q.window.Milliseconds()), //nolint:gosec
+		HalfLife:     int32(q.halfLife.Seconds()),
+		Subsystems:   metrics.EnabledSubsystems(q.enabled),
+		Options:      q.options,
+		Aggregations: q.aggregations,
+	}
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(key)
+	return string(data)
+}
+
+// alignedStats - вычисление снимка общего потока GetStats и Watch. События сущностей
+// отдаются только произошедшие после предыдущей границы.
+func (s *monitoringServer) alignedStats(q engineQuery) func(at time.Time) *pb.StatsResponse {
+	return func(at time.Time) *pb.StatsResponse {
+		stats, meta, _ := s.engineSnapshot(at, q)
+		q.since = at
+		stats.Meta = meta
+		return stats
+	}
+}
+
+// alignedUpdates - вычисление обновлений общего потока Subscribe.
+func (s *monitoringServer) alignedUpdates(q engineQuery) func(at time.Time) []*pb.SubsystemUpdate {
+	return func(at time.Time) []*pb.SubsystemUpdate {
+		updates := s.engineUpdates(at, q)
+		q.since = at
+		return updates
+	}
+}
+
+// streamAligned - поток снимков общего движка на границах времени, кратных interval.
+// Снимок вычисляется один раз для всех клиентов с теми же параметрами; каждый клиент
+// получает свою копию со своим порядковым номером.
+func (s *monitoringServer) streamAligned(ctx context.Context,
+	interval time.Duration,
+	heartbeat time.Duration,
+	q engineQuery,
+	send func(*pb.StatsResponse) error,
+) error {
+	feed, unsubscribe := s.statsFeeds.subscribe(feedKey(q, interval), interval, s.alignedStats(q))
+	defer unsubscribe()
+	status := s.engineProgress(heartbeat, q)
+	defer status.Stop()
+
+	var sequence uint64
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("Client disconnected")
+			return nil
+		case now := <-status.C():
+			sequence++
+			meta := s.stamp(nil, sequence, q.enabled)
+			if err := send(&pb.StatsResponse{Status: status.status(now), Meta: meta}); err != nil {
+				s.log.Error(fmt.Sprintf("Failed to send stream status: %v", err))
+				return err
+			}
+		case shared := <-feed:
+			// Пока движок не накопил окно запроса, общие снимки клиенту не отдаются
+			if !status.warm(time.Now()) {
+				continue
+			}
+			stats := proto.Clone(shared).(*pb.StatsResponse) //nolint:forcetypeassert
+			sequence++
+			stats.Meta = s.stamp(stats.Meta, sequence, q.enabled)
+			if err := send(stats); err != nil {
+				s.log.Error(fmt.Sprintf("Failed to send stats: %v", err))
+				return err
+			}
+			status.sentAll()
+		}
+	}
+}
+
+// subscribeAligned - поток обновлений по подсистемам на границах времени, кратных interval.
+func (s *monitoringServer) subscribeAligned(ctx context.Context,
+	interval time.Duration,
+	heartbeat time.Duration,
+	q engineQuery,
+	send func(*pb.SubsystemUpdate) error,
+) error {
+	feed, unsubscribe := s.updateFeeds.subscribe(feedKey(q, interval), interval, s.alignedUpdates(q))
+	defer unsubscribe()
+	status := s.engineProgress(heartbeat, q)
+	defer status.Stop()
+
+	var sequence uint64
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("Client disconnected")
+			return nil
+		case now := <-status.C():
+			sequence++
+			if err := send(&pb.SubsystemUpdate{
+				Time:   timestamppb.New(now),
+				Status: status.status(now),
+				Meta:   s.stamp(nil, sequence, q.enabled),
+			}); err != nil {
+				s.log.Error(fmt.Sprintf("Failed to send stream status: %v", err))
+				return err
+			}
+		case shared := <-feed:
+			if !status.warm(time.Now()) {
+				continue
+			}
+			for _, u := range shared {
+				update := proto.Clone(u).(*pb.SubsystemUpdate) //nolint:forcetypeassert
+				sequence++
+				update.Meta = s.stamp(update.Meta, sequence, q.enabled)
+				if err := send(update); err != nil {
+					s.log.Error(fmt.Sprintf("Failed to send update: %v", err))
+					return err
+				}
+				status.sent(update.GetSubsystem())
+			}
+		}
+	}
+}
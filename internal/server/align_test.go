@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+func TestNextBoundary(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		now      time.Time
+		interval time.Duration
+		want     time.Time
+	}{
+		{"middle of interval", base.Add(3 * time.Second), 5 * time.Second, base.Add(5 * time.Second)},
+		{"on boundary", base.Add(10 * time.Second), 5 * time.Second, base.Add(15 * time.Second)},
+		{"minute", base.Add(59 * time.Second), time.Minute, base.Add(time.Minute)},
+		{"milliseconds", base.Add(1234 * time.Millisecond), 250 * time.Millisecond, base.Add(1250 * time.Millisecond)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextBoundary(tt.now, tt.interval); !got.Equal(tt.want) {
+				t.Errorf("nextBoundary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeedSetShares(t *testing.T) {
+	var fs feedSet[time.Time]
+	var calls atomic.Int32
+	compute := func(at time.Time) time.Time {
+		calls.Add(1)
+		return at
+	}
+
+	first, unsubscribeFirst := fs.subscribe("a", 50*time.Millisecond, compute)
+	second, unsubscribeSecond := fs.subscribe("a", 50*time.Millisecond, compute)
+	if fs.len() != 1 {
+		t.Fatalf("feeds = %d, want 1 shared feed", fs.len())
+	}
+
+	// Оба подписчика получают один и тот же снимок, вычисленный один раз
+	a, b := <-first, <-second
+	if !a.Equal(b) {
+		t.Errorf("subscribers got %v and %v, want the same boundary", a, b)
+	}
+	if a.UnixNano()%int64(50*time.Millisecond) != 0 {
+		t.Errorf("boundary %v is not aligned to 50ms", a)
+	}
+	if got := calls.Load(); got > 2 {
+		t.Errorf("compute calls = %d, want one per boundary", got)
+	}
+
+	// Поток останавливается вместе с последним подписчиком
+	unsubscribeFirst()
+	if fs.len() != 1 {
+		t.Errorf("feeds = %d after first unsubscribe, want 1", fs.len())
+	}
+	unsubscribeSecond()
+	if fs.len() != 0 {
+		t.Errorf("feeds = %d after last unsubscribe, want 0", fs.len())
+	}
+}
+
+func TestGetStatsAligned(t *testing.T) {
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	// Клиенты, подключившиеся в разное время, получают одинаковые окна
+	req := &pb.StatsRequest{IntervalMs: 200, DurationMs: 200, Align: true}
+	var ends [2]time.Time
+	for i := range ends {
+		stream, err := client.GetStats(ctx, req)
+		if err != nil {
+			t.Fatalf("GetStats() unexpected error: %v", err)
+		}
+		defer func() {
+			_ = stream.CloseSend()
+		}()
+		if i == 0 {
+			time.Sleep(30 * time.Millisecond)
+		}
+		stats, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() unexpected error: %v", err)
+		}
+		ends[i] = stats.GetMeta().GetWindowEnd().AsTime()
+	}
+
+	if ends[0].UnixNano()%int64(200*time.Millisecond) != 0 {
+		t.Errorf("window end %v is not aligned to 200ms", ends[0])
+	}
+	// Второй клиент мог подключиться после границы: его окно сдвинуто ровно на N
+	if diff := ends[1].Sub(ends[0]); diff%(200*time.Millisecond) != 0 {
+		t.Errorf("window ends %v and %v differ by %v, want a multiple of 200ms", ends[0], ends[1], diff)
+	}
+}
@@ -1,33 +1,51 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"time"
 
 	"github.com/shagrat164/system-monitoring-daemon/internal/config"
 	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
 	"github.com/shagrat164/system-monitoring-daemon/internal/metrics"
 	pb "github.com/shagrat164/system-monitoring-daemon/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Run - запускает gRPC-сервер.
 func Run(cfg *config.Config, log *logger.Logger) error {
+	if err := validatePolicy(cfg.Backpressure.Policy); err != nil {
+		return err
+	}
+
 	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	// Создаем буферизированный канал на 10 элементов
-	loadChan := make(chan *pb.StatsResponse, 10)
+	// Общий движок сбора: из его замеров отвечают все запросы и потоки
+	engine := metrics.NewEngine(cfg, log, metrics.RealFileReader{}, commander(cfg))
+	go engine.Run(context.Background())
+
+	hostname, bootID := metrics.HostInfo(metrics.RealFileReader{})
 
-	srv := grpc.NewServer()
+	srv := grpc.NewServer(keepaliveOptions(cfg.Keepalive)...)
 	pb.RegisterMonitoringServer(srv, &monitoringServer{
-		cfg:         cfg,
-		log:         log,
-		metricsChan: loadChan,
+		cfg:      cfg,
+		log:      log,
+		engine:   engine,
+		hostname: hostname,
+		bootID:   bootID,
 	})
+	// Служебный интерфейс на том же порту
+	pb.RegisterAdminServer(srv, &adminServer{engine: engine})
 
 	// Включаем reflection для удобства отладки с grpcurl
 	reflection.Register(srv)
@@ -36,37 +54,531 @@ func Run(cfg *config.Config, log *logger.Logger) error {
 	return srv.Serve(lis)
 }
 
+// keepaliveOptions - параметры keepalive сервера: мёртвое соединение закрывается, даже если
+// поток долго молчит, а клиентам разрешены пинги не чаще min_time.
+func keepaliveOptions(cfg config.KeepaliveConfig) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    time.Duration(cfg.Time) * time.Second,
+			Timeout: time.Duration(cfg.Timeout) * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             time.Duration(cfg.MinTime) * time.Second,
+			PermitWithoutStream: cfg.PermitWithoutStream,
+		}),
+	}
+}
+
+// commander - выполнение внешних команд коллекторов с ограничением вывода из конфигурации.
+func commander(cfg *config.Config) metrics.RealCommander {
+	return metrics.RealCommander{MaxOutput: cfg.Commands.MaxOutputKB << 10}
+}
+
 // monitoringServer - реализует интерфейс MonitoringServer.
 type monitoringServer struct {
 	pb.UnimplementedMonitoringServer
-	cfg         *config.Config
-	log         *logger.Logger
-	metricsChan chan *pb.StatsResponse
+	cfg      *config.Config
+	log      *logger.Logger
+	engine   *metrics.Engine
+	hostname string
+	bootID   string
+
+	// Общие потоки выровненных снимков по параметрам запроса
+	statsFeeds  feedSet[*pb.StatsResponse]
+	updateFeeds feedSet[[]*pb.SubsystemUpdate]
+}
+
+// stamp - дополняет метаданные снимка сведениями о хосте, номером сообщения в потоке
+// и перезапусками коллекторов выбранных подсистем.
+func (s *monitoringServer) stamp(meta *pb.SnapshotMeta, sequence uint64, enabled config.MetricsConfig) *pb.SnapshotMeta {
+	if meta == nil {
+		meta = &pb.SnapshotMeta{}
+	}
+	meta.Sequence = sequence
+	meta.Hostname = s.hostname
+	meta.BootId = s.bootID
+	// Перезапуски коллекторов и деградированные подсистемы запроса
+	s.engine.Supervisor().Annotate(meta, enabled, time.Now())
+	return meta
 }
 
 // GetStats - реализует поток статистики.
 func (s *monitoringServer) GetStats(req *pb.StatsRequest, stream pb.Monitoring_GetStatsServer) error {
 	s.log.Info("New client connected to GetStats stream")
 
-	// Передаём RealFileReader для реального чтения файла
-	reader := metrics.RealFileReader{}
-	// RealCommander для реального выполнения команд
-	cmd := metrics.RealCommander{}
+	interval, duration, err := validateStatsRequest(s.cfg.Request, req)
+	if err != nil {
+		return err
+	}
+
+	// Отдаём только запрошенные клиентом подсистемы
+	enabled, err := metrics.SelectSubsystems(s.cfg.Enabled, req.GetSubsystems())
+	if err != nil {
+		return invalidArgument("%v", err)
+	}
+
+	halfLife, err := validateAveraging(s.cfg.Request, req.GetAveraging(), req.GetHalfLife(), duration)
+	if err != nil {
+		return err
+	}
+	// Снимки раз в N: движок должен опрашивать подсистемы не реже
+	if err := validateResolution(s.engine, enabled, interval); err != nil {
+		return err
+	}
+	if err := validateRetention(s.engine, duration, halfLife); err != nil {
+		return err
+	}
+	heartbeat := time.Duration(req.GetHeartbeat()) * time.Second
+
+	// Сбор не ждёт клиента: медленный клиент переполняет только свою очередь отправки
+	out := newOutbox(s.cfg.Backpressure, stream.Send, statsKind)
+	defer out.Close()
+	defer s.engine.Self().Stream("GetStats", out.Len)()
+
+	query := engineQuery{
+		window:       duration,
+		halfLife:     halfLife,
+		enabled:      enabled,
+		options:      req.GetOptions(),
+		aggregations: req.GetAggregations(),
+	}
+	// Выровненные снимки считает общий движок один раз для всех клиентов с теми же параметрами
+	if req.GetAlign() {
+		return s.streamAligned(stream.Context(), interval, heartbeat, query, out.Send)
+	}
+	// Окно и EWMA ведёт общий движок: поток не запускает своих коллекторов, а значения
+	// и агрегаты берутся из одних и тех же замеров
+	return s.streamEngine(stream.Context(), interval, heartbeat, query, out.Send)
+}
+
+// Subscribe - реализует поток обновлений по подсистемам.
+func (s *monitoringServer) Subscribe(req *pb.StatsRequest, stream pb.Monitoring_SubscribeServer) error {
+	s.log.Info("New client connected to Subscribe stream")
+
+	interval, duration, err := validateStatsRequest(s.cfg.Request, req)
+	if err != nil {
+		return err
+	}
+
+	enabled, err := metrics.SelectSubsystems(s.cfg.Enabled, req.GetSubsystems())
+	if err != nil {
+		return invalidArgument("%v", err)
+	}
+
+	halfLife, err := validateAveraging(s.cfg.Request, req.GetAveraging(), req.GetHalfLife(), duration)
+	if err != nil {
+		return err
+	}
+	if err := validateResolution(s.engine, enabled, interval); err != nil {
+		return err
+	}
+	if err := validateRetention(s.engine, duration, halfLife); err != nil {
+		return err
+	}
+	heartbeat := time.Duration(req.GetHeartbeat()) * time.Second
 
-	// Запускаем сбор данных с учетом N и M из запроса клиента
-	go metrics.CollectMetrics(stream.Context(), s.cfg, s.log, s.metricsChan, req.Interval, req.Duration, reader, cmd)
+	out := newOutbox(s.cfg.Backpressure, stream.Send, updateKind)
+	defer out.Close()
+	defer s.engine.Self().Stream("Subscribe", out.Len)()
+
+	query := engineQuery{
+		window:       duration,
+		halfLife:     halfLife,
+		enabled:      enabled,
+		options:      req.GetOptions(),
+		aggregations: req.GetAggregations(),
+	}
+	if req.GetAlign() {
+		return s.subscribeAligned(stream.Context(), interval, heartbeat, query, out.Send)
+	}
+	return s.subscribeEngine(stream.Context(), interval, heartbeat, query, out.Send)
+}
+
+// GetSnapshot - возвращает усреднённые значения за запрошенный период по уже накопленным замерам.
+// Ответ отдаётся сразу; если замеров меньше, чем нужно, это видно по покрытию.
+func (s *monitoringServer) GetSnapshot(_ context.Context, req *pb.SnapshotRequest) (*pb.SnapshotResponse, error) {
+	duration, err := validateSnapshotRequest(s.cfg.Request, req)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := metrics.SelectSubsystems(s.cfg.Enabled, req.GetSubsystems())
+	if err != nil {
+		return nil, invalidArgument("%v", err)
+	}
+	halfLife, err := validateAveraging(s.cfg.Request, req.GetAveraging(), req.GetHalfLife(),
+		time.Duration(duration)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRetention(s.engine, time.Duration(duration)*time.Second, halfLife); err != nil {
+		return nil, err
+	}
+
+	stats, meta, covered := s.engineSnapshot(time.Now(), engineQuery{
+		window:       time.Duration(duration) * time.Second,
+		halfLife:     halfLife,
+		enabled:      enabled,
+		options:      req.GetOptions(),
+		aggregations: req.GetAggregations(),
+	})
+
+	meta = s.stamp(meta, 0, enabled)
+	return &pb.SnapshotResponse{
+		Stats:          stats,
+		Duration:       duration,
+		CoveredSeconds: covered.Seconds(),
+		Coverage:       meta.GetSubsystems(),
+		Meta:           meta,
+	}, nil
+}
+
+// Watch - поток статистики по накопленным движком замерам. Клиент может в любой момент
+// прислать новые N, M и подсистемы: ответ по уже собранной истории отправляется сразу.
+func (s *monitoringServer) Watch(stream pb.Monitoring_WatchServer) error {
+	s.log.Info("New client connected to Watch stream")
+
+	out := newOutbox(s.cfg.Backpressure, stream.Send, statsKind)
+	defer out.Close()
+	defer s.engine.Self().Stream("Watch", out.Len)()
+
+	requests := make(chan *pb.StatsRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		query       engineQuery
+		ticker      *time.Ticker
+		tick        <-chan time.Time
+		aligned     <-chan *pb.StatsResponse
+		unsubscribe func()
+		sequence    uint64
+	)
+	// stop - останавливает поток снимков с прежними настройками
+	stop := func() {
+		if ticker != nil {
+			ticker.Stop()
+			ticker, tick = nil, nil
+		}
+		if unsubscribe != nil {
+			unsubscribe()
+			aligned, unsubscribe = nil, nil
+		}
+	}
+	defer stop()
 
-	// Передаем данные из канала в поток
 	for {
 		select {
-		case stats := <-s.metricsChan:
-			if err := stream.Send(stats); err != nil {
+		case <-stream.Context().Done():
+			s.log.Info("Client disconnected")
+			return nil
+		case err := <-recvErr:
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			// Клиент больше не будет менять настройки - продолжаем с текущими
+			recvErr = nil
+			if tick == nil && aligned == nil {
+				return nil
+			}
+			continue
+		case newReq := <-requests:
+			interval, duration, err := validateStatsRequest(s.cfg.Request, newReq)
+			if err != nil {
+				return err
+			}
+			enabled, err := metrics.SelectSubsystems(s.cfg.Enabled, newReq.GetSubsystems())
+			if err != nil {
+				return invalidArgument("%v", err)
+			}
+			halfLife, err := validateAveraging(s.cfg.Request, newReq.GetAveraging(), newReq.GetHalfLife(), duration)
+			if err != nil {
+				return err
+			}
+			if err := validateResolution(s.engine, enabled, interval); err != nil {
+				return err
+			}
+			if err := validateRetention(s.engine, duration, halfLife); err != nil {
+				return err
+			}
+			query = engineQuery{
+				window:       duration,
+				halfLife:     halfLife,
+				enabled:      enabled,
+				options:      newReq.GetOptions(),
+				aggregations: newReq.GetAggregations(),
+			}
+
+			stop()
+			s.log.Debug(fmt.Sprintf("Watch settings changed: interval=%v duration=%v align=%v",
+				interval, duration, newReq.GetAlign()))
+			// Выровненный снимок приходит на ближайшей границе, а не сразу
+			if newReq.GetAlign() {
+				aligned, unsubscribe = s.statsFeeds.subscribe(feedKey(query, interval), interval,
+					s.alignedStats(query))
+				continue
+			}
+			ticker = time.NewTicker(interval)
+			tick = ticker.C
+		case shared := <-aligned:
+			stats := proto.Clone(shared).(*pb.StatsResponse) //nolint:forcetypeassert
+			sequence++
+			stats.Meta = s.stamp(stats.Meta, sequence, query.enabled)
+			if err := out.Send(stats); err != nil {
 				s.log.Error(fmt.Sprintf("Failed to send stats: %v", err))
 				return err
 			}
-		case <-stream.Context().Done():
+			continue
+		case <-tick:
+		}
+
+		// После первого ответа с новыми настройками события отдаются только новые
+		now := time.Now()
+		stats, meta, _ := s.engineSnapshot(now, query)
+		query.since = now
+		sequence++
+		stats.Meta = s.stamp(meta, sequence, query.enabled)
+		if err := out.Send(stats); err != nil {
+			s.log.Error(fmt.Sprintf("Failed to send stats: %v", err))
+			return err
+		}
+	}
+}
+
+// streamEngine - поток снимков общего движка: первый ответ сразу, как только движок накопил
+// окно запроса, далее раз в interval. Окно копится только после запуска демона, а для EWMA
+// не копится вовсе.
+func (s *monitoringServer) streamEngine(ctx context.Context,
+	interval time.Duration,
+	heartbeat time.Duration,
+	q engineQuery,
+	send func(*pb.StatsResponse) error,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	status := s.engineProgress(heartbeat, q)
+	defer status.Stop()
+
+	var sequence uint64
+	snapshot := func() error {
+		now := time.Now()
+		if !status.warm(now) {
+			return nil
+		}
+		stats, meta, _ := s.engineSnapshot(now, q)
+		q.since = now
+		sequence++
+		stats.Meta = s.stamp(meta, sequence, q.enabled)
+		if err := send(stats); err != nil {
+			s.log.Error(fmt.Sprintf("Failed to send stats: %v", err))
+			return err
+		}
+		status.sentAll()
+		return nil
+	}
+
+	if err := snapshot(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
 			s.log.Info("Client disconnected")
 			return nil
+		case now := <-status.C():
+			sequence++
+			meta := s.stamp(nil, sequence, q.enabled)
+			if err := send(&pb.StatsResponse{Status: status.status(now), Meta: meta}); err != nil {
+				s.log.Error(fmt.Sprintf("Failed to send stream status: %v", err))
+				return err
+			}
+		case <-ticker.C:
+			if err := snapshot(); err != nil {
+				return err
+			}
 		}
 	}
 }
+
+// subscribeEngine - поток обновлений общего движка по подсистемам: каждая подсистема с данными
+// приходит отдельным сообщением, как только движок накопил окно запроса, и далее раз в interval.
+func (s *monitoringServer) subscribeEngine(ctx context.Context,
+	interval time.Duration,
+	heartbeat time.Duration,
+	q engineQuery,
+	send func(*pb.SubsystemUpdate) error,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	status := s.engineProgress(heartbeat, q)
+	defer status.Stop()
+
+	var sequence uint64
+	updates := func() error {
+		now := time.Now()
+		if !status.warm(now) {
+			return nil
+		}
+		for _, update := range s.engineUpdates(now, q) {
+			sequence++
+			update.Meta = s.stamp(update.Meta, sequence, q.enabled)
+			if err := send(update); err != nil {
+				s.log.Error(fmt.Sprintf("Failed to send update: %v", err))
+				return err
+			}
+			status.sent(update.GetSubsystem())
+		}
+
+		q.since = now
+		return nil
+	}
+
+	if err := updates(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("Client disconnected")
+			return nil
+		case now := <-status.C():
+			sequence++
+			if err := send(&pb.SubsystemUpdate{
+				Time:   timestamppb.New(now),
+				Status: status.status(now),
+				Meta:   s.stamp(nil, sequence, q.enabled),
+			}); err != nil {
+				s.log.Error(fmt.Sprintf("Failed to send stream status: %v", err))
+				return err
+			}
+		case <-ticker.C:
+			if err := updates(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// engineProgress - служебные сообщения потока общего движка: окно копится с запуска движка,
+// в режиме EWMA - не копится.
+func (s *monitoringServer) engineProgress(heartbeat time.Duration, q engineQuery) *progress {
+	if q.halfLife > 0 {
+		return newProgress(time.Now(), heartbeat, config.MetricsConfig{}, 0)
+	}
+	return newProgress(s.engine.Started(), heartbeat, q.enabled, q.window)
+}
+
+// engineUpdates - обновления общего движка по подсистемам на момент now. Невыбранная подсистема
+// или подсистема без замеров не попадает в результат: отсутствие обновления означает "нет данных".
+func (s *monitoringServer) engineUpdates(now time.Time, q engineQuery) []*pb.SubsystemUpdate {
+	var updates []*pb.SubsystemUpdate
+	for _, subsystem := range metrics.Subsystems {
+		part := q
+		part.enabled, _ = metrics.SelectSubsystems(q.enabled, []string{subsystem})
+		stats, meta, _ := s.engineSnapshot(now, part)
+		if len(meta.GetSubsystems()) == 0 || meta.GetSubsystems()[0].GetSamples() == 0 {
+			continue
+		}
+
+		update := metrics.NewSubsystemUpdate(subsystem, stats, now)
+		update.Aggregates = stats.GetAggregates()
+		update.Events = stats.GetEvents()
+		update.Meta = meta
+		updates = append(updates, update)
+	}
+	return updates
+}
+
+// QueryHistory - возвращает историю метрики за период из хранилища движка.
+func (s *monitoringServer) QueryHistory(_ context.Context, req *pb.HistoryRequest) (*pb.HistoryResponse, error) {
+	from, to, step, err := validateHistoryRequest(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	found, resolution := s.engine.History().Query(req.GetSubsystem(), req.GetMetric(), req.GetLabel(), from, to, step)
+
+	resp := &pb.HistoryResponse{Resolution: int32(resolution.Seconds())}
+	for _, series := range found {
+		points := make([]*pb.HistoryPoint, 0, len(series.Points))
+		for _, p := range series.Points {
+			points = append(points, &pb.HistoryPoint{
+				Time:  timestamppb.New(p.Time),
+				Value: p.Avg,
+				Min:   p.Min,
+				Max:   p.Max,
+				Count: int32(p.Count), //nolint:gosec
+			})
+		}
+		resp.Series = append(resp.Series, &pb.HistorySeries{
+			Subsystem: series.Key.Subsystem,
+			Metric:    series.Key.Metric,
+			Label:     series.Key.Label,
+			Points:    points,
+		})
+	}
+
+	return resp, nil
+}
+
+// engineQuery - параметры снимка общего движка из запроса клиента.
+type engineQuery struct {
+	window       time.Duration // Период усреднения M
+	halfLife     time.Duration // Период полураспада EWMA (0 - среднее за окно)
+	enabled      config.MetricsConfig
+	options      *pb.SubsystemOptions
+	aggregations []*pb.AggregationRequest
+	since        time.Time // События сущностей после этого момента (нулевое - за период window)
+}
+
+// engineSnapshot - собирает снимок движка с агрегатами и метаданными окна.
+// Общее покрытие считается по наименее покрытой подсистеме.
+func (s *monitoringServer) engineSnapshot(now time.Time, q engineQuery) (*pb.StatsResponse, *pb.SnapshotMeta, time.Duration) {
+	var (
+		stats    *pb.StatsResponse
+		coverage []metrics.Coverage
+	)
+	window := q.window
+	if q.halfLife > 0 {
+		stats, coverage = s.engine.EWMASnapshot(now, q.halfLife, q.enabled)
+		window = q.halfLife
+	} else {
+		stats, coverage = s.engine.Snapshot(now, q.window, q.enabled)
+	}
+	stats.Aggregates = s.engine.Aggregates(now, q.window, q.enabled, q.aggregations)
+	since := q.since
+	if since.IsZero() {
+		since = now.Add(-q.window)
+	}
+	stats.Events = s.engine.Events(since, now, q.enabled)
+	metrics.FilterStats(stats, q.options)
+
+	meta := &pb.SnapshotMeta{
+		WindowStart: timestamppb.New(now.Add(-window)),
+		WindowEnd:   timestamppb.New(now),
+		HalfLife:    int32(q.halfLife.Seconds()),
+	}
+	covered := window
+	for _, c := range coverage {
+		covered = min(covered, c.Covered)
+		meta.Subsystems = append(meta.Subsystems, c.Proto(s.cfg.Coverage.MinRatio))
+	}
+	if len(coverage) == 0 {
+		covered = 0
+	}
+
+	return stats, meta, covered
+}
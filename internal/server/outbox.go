@@ -0,0 +1,196 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Политики переполнения очереди отправки клиенту.
+const (
+	policyDropOldest = "drop_oldest" // Отбросить самое старое сообщение очереди
+	policyLatest     = "latest"      // Оставить только последнее сообщение каждого вида
+	policyDisconnect = "disconnect"  // Отбросить новое сообщение, после max_dropped - отключить клиента
+)
+
+// validatePolicy - проверяет политику переполнения из конфигурации.
+func validatePolicy(policy string) error {
+	switch policy {
+	case policyDropOldest, policyLatest, policyDisconnect:
+		return nil
+	}
+	return fmt.Errorf("unknown backpressure policy %q: want %s, %s or %s",
+		policy, policyDropOldest, policyLatest, policyDisconnect)
+}
+
+// message - сообщение потока с метаданными.
+type message interface {
+	GetMeta() *pb.SnapshotMeta
+}
+
+// outbox - очередь отправки сообщений одному клиенту. Поток ставит сообщения в очередь, не дожидаясь
+// клиента, а отправляет их отдельная горутина: медленный клиент переполняет только свою очередь.
+// Лишние сообщения отбрасываются по политике, их число клиент получает в meta.dropped.
+type outbox[T message] struct {
+	cfg  config.BackpressureConfig
+	send func(T) error
+	kind func(T) string // Вид сообщения: политика latest заменяет в очереди сообщение того же вида
+
+	mu      sync.Mutex
+	queue   []T
+	dropped uint64
+	err     error // Ошибка отправки или отключение клиента: новые сообщения не принимаются
+
+	ready chan struct{}
+	stop  chan struct{}
+}
+
+// newOutbox - создаёт очередь отправки и запускает горутину отправки.
+func newOutbox[T message](cfg config.BackpressureConfig, send func(T) error, kind func(T) string) *outbox[T] {
+	cfg.Queue = max(cfg.Queue, 1)
+	o := &outbox[T]{
+		cfg:   cfg,
+		send:  send,
+		kind:  kind,
+		ready: make(chan struct{}, 1),
+		stop:  make(chan struct{}),
+	}
+	go o.run()
+	return o
+}
+
+// Send - ставит сообщение в очередь. Ошибка означает, что поток нужно завершить: клиент
+// отключён политикой disconnect или отправка предыдущего сообщения не удалась.
+func (o *outbox[T]) Send(msg T) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return o.err
+	}
+
+	if o.cfg.Policy == policyLatest {
+		kind := o.kind(msg)
+		kept := o.queue[:0]
+		for _, queued := range o.queue {
+			if o.kind(queued) == kind {
+				o.dropped++
+				continue
+			}
+			kept = append(kept, queued)
+		}
+		clear(o.queue[len(kept):])
+		o.queue = kept
+	}
+
+	if len(o.queue) >= o.cfg.Queue {
+		o.dropped++
+		if o.cfg.Policy == policyDisconnect {
+			if o.dropped > uint64(max(o.cfg.MaxDropped, 0)) {
+				o.err = status.Errorf(codes.ResourceExhausted, "client is too slow: %d messages dropped", o.dropped)
+				o.reset()
+				return o.err
+			}
+			return nil
+		}
+		n := copy(o.queue, o.queue[1:])
+		clear(o.queue[n:])
+		o.queue = o.queue[:n]
+	}
+
+	o.queue = append(o.queue, msg)
+	select {
+	case o.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Len - число сообщений в очереди.
+func (o *outbox[T]) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.queue)
+}
+
+// Close - останавливает отправку; неотправленные сообщения отбрасываются. Отправку, которая
+// уже идёт, Close не ждёт: зависшую на клиенте отправку прерывает gRPC, отменяя поток
+// после выхода из обработчика.
+func (o *outbox[T]) Close() {
+	close(o.stop)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.reset()
+}
+
+// run - отправляет сообщения очереди по порядку, проставляя число отброшенных.
+func (o *outbox[T]) run() {
+	for {
+		select {
+		case <-o.stop:
+			return
+		case <-o.ready:
+		}
+
+		for {
+			msg, dropped, ok := o.pop()
+			if !ok {
+				break
+			}
+			select {
+			case <-o.stop:
+				return
+			default:
+			}
+			if meta := msg.GetMeta(); meta != nil {
+				meta.Dropped = dropped
+			}
+			if err := o.send(msg); err != nil {
+				o.mu.Lock()
+				o.err = err
+				o.reset()
+				o.mu.Unlock()
+				return
+			}
+		}
+	}
+}
+
+// pop - забирает первое сообщение очереди. После ошибки очередь не отправляется.
+func (o *outbox[T]) pop() (msg T, dropped uint64, ok bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil || len(o.queue) == 0 {
+		return msg, 0, false
+	}
+	msg = o.queue[0]
+	n := copy(o.queue, o.queue[1:])
+	clear(o.queue[n:])
+	o.queue = o.queue[:n]
+	return msg, o.dropped, true
+}
+
+// reset - отбрасывает сообщения очереди; вызывается под mu.
+func (o *outbox[T]) reset() {
+	clear(o.queue)
+	o.queue = o.queue[:0]
+}
+
+// statsKind - вид сообщения GetStats и Watch: снимок или служебное сообщение.
+func statsKind(stats *pb.StatsResponse) string {
+	if stats.GetStatus() != nil {
+		return "status"
+	}
+	return "stats"
+}
+
+// updateKind - вид сообщения Subscribe: обновление подсистемы или служебное сообщение.
+func updateKind(update *pb.SubsystemUpdate) string {
+	if update.GetStatus() != nil {
+		return "status"
+	}
+	return update.GetSubsystem()
+}
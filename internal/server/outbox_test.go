@@ -0,0 +1,168 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// slowClient - клиент, который читает сообщение только по сигналу.
+type slowClient struct {
+	sending chan struct{} // Отправка началась
+	read    chan struct{}
+	got     chan *pb.SubsystemUpdate
+}
+
+func newSlowClient() *slowClient {
+	return &slowClient{
+		sending: make(chan struct{}, 100),
+		read:    make(chan struct{}),
+		got:     make(chan *pb.SubsystemUpdate, 100),
+	}
+}
+
+func (c *slowClient) Send(update *pb.SubsystemUpdate) error {
+	c.sending <- struct{}{}
+	<-c.read
+	c.got <- update
+	return nil
+}
+
+func update(subsystem string, sequence uint64) *pb.SubsystemUpdate {
+	return &pb.SubsystemUpdate{Subsystem: subsystem, Meta: &pb.SnapshotMeta{Sequence: sequence}}
+}
+
+func TestOutbox(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		subsystems  []string // Сообщения, поставленные в очередь, пока клиент читает первое
+		want        []uint64 // Порядковые номера полученных клиентом сообщений
+		wantDropped uint64
+		wantErr     codes.Code
+	}{
+		{
+			name:        "drop oldest",
+			policy:      policyDropOldest,
+			subsystems:  []string{"cpu", "cpu", "cpu", "cpu"},
+			want:        []uint64{1, 4, 5},
+			wantDropped: 2,
+		},
+		{
+			name:        "latest per subsystem",
+			policy:      policyLatest,
+			subsystems:  []string{"cpu", "disk", "cpu", "disk"},
+			want:        []uint64{1, 4, 5},
+			wantDropped: 2,
+		},
+		{
+			// После отключения сообщения очереди клиенту не отправляются
+			name:        "disconnect after max dropped",
+			policy:      policyDisconnect,
+			subsystems:  []string{"cpu", "cpu", "cpu", "cpu"},
+			want:        []uint64{1},
+			wantDropped: 0,
+			wantErr:     codes.ResourceExhausted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newSlowClient()
+			out := newOutbox(config.BackpressureConfig{Queue: 2, Policy: tt.policy, MaxDropped: 1},
+				client.Send, updateKind)
+			defer out.Close()
+
+			// Первое сообщение уходит клиенту, остальные ждут в очереди
+			if err := out.Send(update("cpu", 1)); err != nil {
+				t.Fatalf("Send() unexpected error: %v", err)
+			}
+			<-client.sending
+
+			var err error
+			for i, subsystem := range tt.subsystems {
+				if err = out.Send(update(subsystem, uint64(i+2))); err != nil { //nolint:gosec
+					break
+				}
+			}
+			if status.Code(err) != tt.wantErr {
+				t.Fatalf("Send() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var sequences []uint64
+			var dropped uint64
+			for i := range tt.want {
+				if i > 0 {
+					<-client.sending
+				}
+				client.read <- struct{}{}
+				got := <-client.got
+				sequences = append(sequences, got.GetMeta().GetSequence())
+				dropped = got.GetMeta().GetDropped()
+			}
+			for i := range tt.want {
+				if sequences[i] != tt.want[i] {
+					t.Fatalf("client got sequences %v, want %v", sequences, tt.want)
+				}
+			}
+			if dropped != tt.wantDropped {
+				t.Errorf("last meta.dropped = %d, want %d", dropped, tt.wantDropped)
+			}
+			select {
+			case <-client.sending:
+				t.Error("outbox sent more messages than expected")
+			case <-time.After(50 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestOutboxCloseStuckSend(t *testing.T) {
+	// Клиент не читает поток: отправка не завершается, пока gRPC не отменит поток
+	unblock := make(chan struct{})
+	defer close(unblock)
+	sending := make(chan struct{}, 1)
+	out := newOutbox(config.BackpressureConfig{Queue: 2, Policy: policyDropOldest},
+		func(*pb.SubsystemUpdate) error {
+			sending <- struct{}{}
+			<-unblock
+			return nil
+		}, updateKind)
+
+	if err := out.Send(update("cpu", 1)); err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+	<-sending
+	if err := out.Send(update("cpu", 2)); err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		out.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close() waits for a stuck send")
+	}
+	if n := out.Len(); n != 0 {
+		t.Errorf("Len() after Close() = %d, want 0", n)
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	for _, policy := range []string{policyDropOldest, policyLatest, policyDisconnect} {
+		if err := validatePolicy(policy); err != nil {
+			t.Errorf("validatePolicy(%q) unexpected error: %v", policy, err)
+		}
+	}
+	if err := validatePolicy("block"); err == nil {
+		t.Error("validatePolicy(\"block\") want error")
+	}
+}
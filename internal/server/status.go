@@ -0,0 +1,103 @@
+package server
+
+import (
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/metrics"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+// progress - служебные сообщения потока. Пока подсистемы "молчат", накапливая окно, клиент
+// раз в period получает накопленные и требуемые секунды по каждой; после первого ответа -
+// heartbeat, если за period не было ни одного ответа с данными.
+type progress struct {
+	period   time.Duration
+	started  time.Time
+	required map[string]time.Duration // Подсистемы, ещё не отправившие первый ответ
+	ticker   *time.Ticker
+}
+
+// newProgress - создаёт служебные сообщения потока, окно window которого копится с момента
+// started. При period == 0 сообщения не отправляются.
+func newProgress(started time.Time,
+	period time.Duration,
+	enabled config.MetricsConfig,
+	window time.Duration,
+) *progress {
+	p := &progress{period: period, started: started, required: make(map[string]time.Duration)}
+	for _, subsystem := range metrics.EnabledSubsystems(enabled) {
+		p.required[subsystem] = window
+	}
+	if period > 0 {
+		p.ticker = time.NewTicker(period)
+	}
+	return p
+}
+
+// warm - накоплено ли к моменту now окно всех подсистем потока.
+func (p *progress) warm(now time.Time) bool {
+	elapsed := now.Sub(p.started)
+	for _, required := range p.required {
+		if elapsed < required {
+			return false
+		}
+	}
+	return true
+}
+
+// C - канал срабатываний; nil, если служебные сообщения не запрошены.
+func (p *progress) C() <-chan time.Time {
+	if p.ticker == nil {
+		return nil
+	}
+	return p.ticker.C
+}
+
+// Stop - останавливает таймер служебных сообщений.
+func (p *progress) Stop() {
+	if p.ticker != nil {
+		p.ticker.Stop()
+	}
+}
+
+// sent - отмечает отправленный ответ с данными подсистем; следующий heartbeat откладывается на period.
+func (p *progress) sent(subsystems ...string) {
+	for _, subsystem := range subsystems {
+		delete(p.required, subsystem)
+	}
+	if p.ticker != nil {
+		p.ticker.Reset(p.period)
+	}
+}
+
+// sentAll - отмечает ответ с данными всех подсистем потока.
+func (p *progress) sentAll() {
+	for subsystem := range p.required {
+		delete(p.required, subsystem)
+	}
+	p.sent()
+}
+
+// status - служебное сообщение на момент now: прогресс накопления, пока окно ещё копится,
+// иначе heartbeat.
+func (p *progress) status(now time.Time) *pb.StreamStatus {
+	if p.warm(now) {
+		return &pb.StreamStatus{Kind: pb.StreamStatusKind_STREAM_HEARTBEAT}
+	}
+
+	status := &pb.StreamStatus{Kind: pb.StreamStatusKind_STREAM_WARMUP}
+	elapsed := now.Sub(p.started)
+	for _, subsystem := range metrics.Subsystems {
+		required, ok := p.required[subsystem]
+		if !ok {
+			continue
+		}
+		status.Warmup = append(status.Warmup, &pb.WarmupProgress{
+			Subsystem:          subsystem,
+			AccumulatedSeconds: min(elapsed, required).Seconds(),
+			RequiredSeconds:    required.Seconds(),
+		})
+	}
+	return status
+}
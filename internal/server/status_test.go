@@ -0,0 +1,55 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+)
+
+func TestProgress(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	enabled := config.MetricsConfig{LoadAvg: true, Disk: true}
+
+	if p := newProgress(t0, 0, enabled, time.Minute); p.C() != nil {
+		t.Fatal("newProgress() without period must not tick")
+	}
+
+	p := newProgress(t0, 5*time.Second, enabled, time.Minute)
+	defer p.Stop()
+
+	status := p.status(t0.Add(20 * time.Second))
+	want := []*pb.WarmupProgress{
+		{Subsystem: "load_avg", AccumulatedSeconds: 20, RequiredSeconds: 60},
+		{Subsystem: "disk", AccumulatedSeconds: 20, RequiredSeconds: 60},
+	}
+	if status.GetKind() != pb.StreamStatusKind_STREAM_WARMUP || len(status.GetWarmup()) != len(want) {
+		t.Fatalf("status() = %v, want warm-up of %v", status, want)
+	}
+	for i, w := range status.GetWarmup() {
+		if w.GetSubsystem() != want[i].GetSubsystem() || w.GetAccumulatedSeconds() != want[i].GetAccumulatedSeconds() ||
+			w.GetRequiredSeconds() != want[i].GetRequiredSeconds() {
+			t.Errorf("status() warmup[%d] = %v, want %v", i, w, want[i])
+		}
+	}
+	if p.warm(t0.Add(59*time.Second)) || !p.warm(t0.Add(time.Minute)) {
+		t.Errorf("warm() must turn true once the window is accumulated")
+	}
+
+	// Первый ответ load average убирает его из прогресса
+	p.sent("load_avg")
+	status = p.status(t0.Add(30 * time.Second))
+	if len(status.GetWarmup()) != 1 || status.GetWarmup()[0].GetSubsystem() != "disk" {
+		t.Errorf("status() warmup = %v, want disk only", status.GetWarmup())
+	}
+	// Накопленное окно - heartbeat, даже если подсистема ещё ничего не отправила
+	if status = p.status(t0.Add(2 * time.Minute)); status.GetKind() != pb.StreamStatusKind_STREAM_HEARTBEAT {
+		t.Errorf("status() = %v, want heartbeat after the window is accumulated", status)
+	}
+
+	p.sentAll()
+	if status = p.status(t0.Add(40 * time.Second)); status.GetKind() != pb.StreamStatusKind_STREAM_HEARTBEAT {
+		t.Errorf("status() = %v, want heartbeat after the first answer", status)
+	}
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/metrics"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Значения N и M, если клиент их не указал (0).
+const (
+	defaultInterval = 5
+	defaultDuration = 15
+)
+
+// validateStatsRequest - проверяет параметры потокового запроса и подставляет значения по умолчанию.
+// N и M в миллисекундах (версия 2 запроса) заменяют секунды и допускают опрос чаще раза в секунду.
+// Ошибки возвращаются со статусом codes.InvalidArgument.
+func validateStatsRequest(limits config.RequestConfig, req *pb.StatsRequest) (interval, duration time.Duration, err error) {
+	interval = time.Duration(req.GetInterval()) * time.Second
+	if req.GetInterval() == 0 {
+		interval = defaultInterval * time.Second
+	}
+	duration = time.Duration(req.GetDuration()) * time.Second
+	if req.GetDuration() == 0 {
+		duration = defaultDuration * time.Second
+	}
+	minInterval := time.Duration(limits.MinInterval) * time.Second
+	minDuration := time.Duration(limits.MinDuration) * time.Second
+	if req.GetIntervalMs() != 0 || req.GetDurationMs() != 0 {
+		if req.GetIntervalMs() != 0 {
+			interval = time.Duration(req.GetIntervalMs()) * time.Millisecond
+		}
+		if req.GetDurationMs() != 0 {
+			duration = time.Duration(req.GetDurationMs()) * time.Millisecond
+		}
+		minInterval = time.Duration(limits.MinIntervalMS) * time.Millisecond
+		minDuration = time.Duration(limits.MinDurationMS) * time.Millisecond
+	}
+
+	maxInterval := time.Duration(limits.MaxInterval) * time.Second
+	if interval < minInterval || interval > maxInterval {
+		return 0, 0, invalidArgument("interval must be between %v and %v, got %v", minInterval, maxInterval, interval)
+	}
+	maxDuration := time.Duration(limits.MaxDuration) * time.Second
+	if duration < minDuration || duration > maxDuration {
+		return 0, 0, invalidArgument("duration must be between %v and %v, got %v", minDuration, maxDuration, duration)
+	}
+	// Период должен состоять из целого числа интервалов, иначе часть окна не усредняется
+	if duration%interval != 0 {
+		return 0, 0, invalidArgument("duration must be a multiple of interval %v, got %v", interval, duration)
+	}
+	// Служебные сообщения не чаще и не реже допустимых интервалов N
+	if hb := req.GetHeartbeat(); hb != 0 && (hb < limits.MinInterval || hb > limits.MaxInterval) {
+		return 0, 0, invalidArgument("heartbeat must be 0 or between %d and %d seconds, got %d",
+			limits.MinInterval, limits.MaxInterval, hb)
+	}
+	if err := validateOptions(req.GetOptions()); err != nil {
+		return 0, 0, err
+	}
+	if err := validateAggregations(req.GetAggregations()); err != nil {
+		return 0, 0, err
+	}
+
+	return interval, duration, nil
+}
+
+// validateResolution - проверяет, что движок опрашивает запрошенные подсистемы не реже раза в interval:
+// иначе соседние снимки потока считались бы по одним и тем же замерам.
+func validateResolution(engine *metrics.Engine, enabled config.MetricsConfig, interval time.Duration) error {
+	for _, subsystem := range metrics.EnabledSubsystems(enabled) {
+		if resolution := engine.Resolution(subsystem); interval < resolution {
+			return invalidArgument("interval %v is below %s resolution %v", interval, subsystem, resolution)
+		}
+	}
+	return nil
+}
+
+// validateRetention - проверяет, что период усреднения M и период полураспада EWMA не длиннее
+// времени хранения замеров движком: окно старше retention не было бы покрыто никогда.
+func validateRetention(engine *metrics.Engine, duration, halfLife time.Duration) error {
+	retention := engine.Retention()
+	if duration > retention {
+		return invalidArgument("duration %v exceeds engine retention %v", duration, retention)
+	}
+	if halfLife > retention {
+		return invalidArgument("half_life %v exceeds engine retention %v", halfLife, retention)
+	}
+	return nil
+}
+
+// validateSnapshotRequest - проверяет параметры разового запроса и подставляет период по умолчанию.
+func validateSnapshotRequest(limits config.RequestConfig, req *pb.SnapshotRequest) (int32, error) {
+	duration := req.GetDuration()
+	if duration == 0 {
+		duration = defaultDuration
+	}
+	if err := validateDuration(limits, duration); err != nil {
+		return 0, err
+	}
+	if err := validateOptions(req.GetOptions()); err != nil {
+		return 0, err
+	}
+	if err := validateAggregations(req.GetAggregations()); err != nil {
+		return 0, err
+	}
+	return duration, nil
+}
+
+// Период истории, если клиент не указал его начало.
+const defaultHistoryRange = 5 * time.Minute
+
+// validateHistoryRequest - проверяет запрос истории и подставляет границы периода по умолчанию.
+func validateHistoryRequest(req *pb.HistoryRequest, now time.Time) (from, to time.Time, step time.Duration, err error) {
+	if _, err := metrics.SelectSubsystems(config.MetricsConfig{}, []string{req.GetSubsystem()}); err != nil {
+		return from, to, 0, invalidArgument("%v", err)
+	}
+
+	to = now
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+	from = to.Add(-defaultHistoryRange)
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+	if from.After(to) {
+		return from, to, 0, invalidArgument("from must not be after to")
+	}
+	if req.GetStep() < 0 {
+		return from, to, 0, invalidArgument("step must not be negative, got %d", req.GetStep())
+	}
+
+	return from, to, time.Duration(req.GetStep()) * time.Second, nil
+}
+
+// validateDuration - проверяет период усреднения M.
+func validateDuration(limits config.RequestConfig, duration int32) error {
+	if duration < limits.MinDuration || duration > limits.MaxDuration {
+		return invalidArgument("duration must be between %d and %d seconds, got %d",
+			limits.MinDuration, limits.MaxDuration, duration)
+	}
+	return nil
+}
+
+// validateOptions - проверяет фильтры подсистем.
+func validateOptions(opts *pb.SubsystemOptions) error {
+	if opts.GetFdTopN() < 0 {
+		return invalidArgument("fd_top_n must not be negative, got %d", opts.GetFdTopN())
+	}
+	return nil
+}
+
+// validateAveraging - проверяет способ усреднения и возвращает период полураспада EWMA
+// (0 - среднее за окно). Без явного периода полураспада используется период усреднения M.
+func validateAveraging(limits config.RequestConfig,
+	mode pb.AveragingMode,
+	halfLife int32,
+	duration time.Duration,
+) (time.Duration, error) {
+	switch mode {
+	case pb.AveragingMode_AVERAGING_WINDOW:
+		if halfLife != 0 {
+			return 0, invalidArgument("half_life requires averaging %s", pb.AveragingMode_AVERAGING_EWMA)
+		}
+		return 0, nil
+	case pb.AveragingMode_AVERAGING_EWMA:
+		hl := time.Duration(halfLife) * time.Second
+		if halfLife == 0 {
+			hl = duration
+		}
+		if hl < time.Duration(limits.MinDuration)*time.Second || hl > time.Duration(limits.MaxDuration)*time.Second {
+			return 0, invalidArgument("half_life must be between %d and %d seconds, got %v",
+				limits.MinDuration, limits.MaxDuration, hl)
+		}
+		return hl, nil
+	default:
+		return 0, invalidArgument("unknown averaging mode %d", mode)
+	}
+}
+
+// validateAggregations - проверяет имена подсистем и функции запрошенных агрегатов.
+func validateAggregations(requests []*pb.AggregationRequest) error {
+	for _, req := range requests {
+		if _, err := metrics.SelectSubsystems(config.MetricsConfig{}, []string{req.GetSubsystem()}); err != nil {
+			return invalidArgument("aggregations: %v", err)
+		}
+		for _, fn := range req.GetFunctions() {
+			if _, ok := pb.Aggregation_name[int32(fn)]; !ok {
+				return invalidArgument("aggregations: unknown function %d for subsystem %q", fn, req.GetSubsystem())
+			}
+		}
+	}
+	return nil
+}
+
+// invalidArgument - ошибка gRPC со статусом InvalidArgument.
+func invalidArgument(format string, args ...any) error {
+	return status.Error(codes.InvalidArgument, fmt.Sprintf(format, args...))
+}
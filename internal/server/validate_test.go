@@ -0,0 +1,276 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
+	"github.com/shagrat164/system-monitoring-daemon/internal/metrics"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestValidateStatsRequest(t *testing.T) {
+	limits := config.NewConfig().Request
+
+	tests := []struct {
+		name         string
+		req          *pb.StatsRequest
+		wantInterval time.Duration
+		wantDuration time.Duration
+		errContains  string
+	}{
+		{"valid", &pb.StatsRequest{Interval: 5, Duration: 15}, 5 * time.Second, 15 * time.Second, ""},
+		{"defaults", &pb.StatsRequest{}, defaultInterval * time.Second, defaultDuration * time.Second, ""},
+		{"equal", &pb.StatsRequest{Interval: 10, Duration: 10}, 10 * time.Second, 10 * time.Second, ""},
+		{"negative interval", &pb.StatsRequest{Interval: -1, Duration: 15}, 0, 0, "interval must be between"},
+		{"interval too large", &pb.StatsRequest{Interval: 301, Duration: 602}, 0, 0, "interval must be between"},
+		{"negative duration", &pb.StatsRequest{Interval: 5, Duration: -15}, 0, 0, "duration must be between"},
+		{"duration too large", &pb.StatsRequest{Interval: 5, Duration: 3605}, 0, 0, "duration must be between"},
+		{"duration less than interval", &pb.StatsRequest{Interval: 10, Duration: 5}, 0, 0, "multiple of interval"},
+		{"not a multiple", &pb.StatsRequest{Interval: 5, Duration: 12}, 0, 0, "multiple of interval"},
+		{"heartbeat", &pb.StatsRequest{Interval: 5, Duration: 60, Heartbeat: 10}, 5 * time.Second, 60 * time.Second, ""},
+		{"negative heartbeat", &pb.StatsRequest{Interval: 5, Duration: 15, Heartbeat: -1}, 0, 0, "heartbeat must be"},
+		{"heartbeat too large", &pb.StatsRequest{Interval: 5, Duration: 15, Heartbeat: 301}, 0, 0, "heartbeat must be"},
+		{"milliseconds", &pb.StatsRequest{IntervalMs: 200, DurationMs: 1000}, 200 * time.Millisecond, time.Second, ""},
+		{
+			"milliseconds replace seconds",
+			&pb.StatsRequest{Interval: 5, Duration: 15, IntervalMs: 500},
+			500 * time.Millisecond, 15 * time.Second, "",
+		},
+		{"milliseconds below min", &pb.StatsRequest{IntervalMs: 50, DurationMs: 100}, 0, 0, "interval must be between"},
+		{"milliseconds not a multiple", &pb.StatsRequest{IntervalMs: 300, DurationMs: 1000}, 0, 0, "multiple of interval"},
+		{"milliseconds duration below min", &pb.StatsRequest{DurationMs: 50}, 0, 0, "duration must be"},
+		{
+			"negative top n",
+			&pb.StatsRequest{Interval: 5, Duration: 15, Options: &pb.SubsystemOptions{FdTopN: -1}},
+			0, 0, "fd_top_n",
+		},
+		{
+			"aggregations",
+			&pb.StatsRequest{Aggregations: []*pb.AggregationRequest{
+				{Subsystem: "cpu", Functions: []pb.Aggregation{pb.Aggregation_AGGREGATION_MAX, pb.Aggregation_AGGREGATION_P95}},
+			}},
+			defaultInterval * time.Second, defaultDuration * time.Second, "",
+		},
+		{
+			"unknown aggregation subsystem",
+			&pb.StatsRequest{Aggregations: []*pb.AggregationRequest{{Subsystem: "gpu"}}},
+			0, 0, "unknown subsystem",
+		},
+		{
+			"unknown aggregation function",
+			&pb.StatsRequest{Aggregations: []*pb.AggregationRequest{{Subsystem: "cpu", Functions: []pb.Aggregation{42}}}},
+			0, 0, "unknown function",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval, duration, err := validateStatsRequest(limits, tt.req)
+			if tt.errContains != "" {
+				if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("validateStatsRequest() error = %v, want InvalidArgument containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateStatsRequest() unexpected error: %v", err)
+			}
+			if interval != tt.wantInterval || duration != tt.wantDuration {
+				t.Errorf("validateStatsRequest() = %v, %v, want %v, %v", interval, duration, tt.wantInterval, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestValidateStatsRequestMinDurationMS(t *testing.T) {
+	limits := config.NewConfig().Request
+	limits.MinDurationMS = 1000
+
+	// Граница M в миллисекундах не зависит от границы N
+	_, _, err := validateStatsRequest(limits, &pb.StatsRequest{IntervalMs: 100, DurationMs: 500})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "duration must be between") {
+		t.Errorf("validateStatsRequest() error = %v, want InvalidArgument for duration below min_duration_ms", err)
+	}
+	if _, _, err := validateStatsRequest(limits, &pb.StatsRequest{IntervalMs: 100, DurationMs: 1000}); err != nil {
+		t.Errorf("validateStatsRequest() unexpected error: %v", err)
+	}
+}
+
+func TestValidateSnapshotRequest(t *testing.T) {
+	limits := config.RequestConfig{MinInterval: 1, MaxInterval: 10, MinDuration: 5, MaxDuration: 60}
+
+	tests := []struct {
+		name         string
+		req          *pb.SnapshotRequest
+		wantDuration int32
+		wantErr      bool
+	}{
+		{"valid", &pb.SnapshotRequest{Duration: 30}, 30, false},
+		{"default", &pb.SnapshotRequest{}, defaultDuration, false},
+		{"below min", &pb.SnapshotRequest{Duration: 1}, 0, true},
+		{"above max", &pb.SnapshotRequest{Duration: 61}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duration, err := validateSnapshotRequest(limits, tt.req)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("validateSnapshotRequest() error = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil || duration != tt.wantDuration {
+				t.Errorf("validateSnapshotRequest() = %d, %v, want %d", duration, err, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestValidateHistoryRequest(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		req      *pb.HistoryRequest
+		wantFrom time.Time
+		wantStep time.Duration
+		wantErr  bool
+	}{
+		{"defaults", &pb.HistoryRequest{Subsystem: "cpu"}, now.Add(-defaultHistoryRange), 0, false},
+		{
+			"explicit range",
+			&pb.HistoryRequest{Subsystem: "disk", From: timestamppb.New(now.Add(-time.Hour)), Step: 60},
+			now.Add(-time.Hour), time.Minute, false,
+		},
+		{"unknown subsystem", &pb.HistoryRequest{Subsystem: "gpu"}, time.Time{}, 0, true},
+		{"from after to", &pb.HistoryRequest{Subsystem: "cpu", From: timestamppb.New(now.Add(time.Hour))}, time.Time{}, 0, true},
+		{"negative step", &pb.HistoryRequest{Subsystem: "cpu", Step: -1}, time.Time{}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, step, err := validateHistoryRequest(tt.req, now)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("validateHistoryRequest() error = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateHistoryRequest() unexpected error: %v", err)
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(now) || step != tt.wantStep {
+				t.Errorf("validateHistoryRequest() = %v, %v, %v, want %v, %v, %v", from, to, step, tt.wantFrom, now, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestValidateAveraging(t *testing.T) {
+	limits := config.RequestConfig{MinDuration: 5, MaxDuration: 60}
+	ewma := pb.AveragingMode_AVERAGING_EWMA
+
+	tests := []struct {
+		name         string
+		mode         pb.AveragingMode
+		halfLife     int32
+		wantHalfLife time.Duration
+		wantErr      bool
+	}{
+		{"window", pb.AveragingMode_AVERAGING_WINDOW, 0, 0, false},
+		{"half life without ewma", pb.AveragingMode_AVERAGING_WINDOW, 10, 0, true},
+		{"ewma default half life", ewma, 0, 30 * time.Second, false},
+		{"ewma explicit half life", ewma, 10, 10 * time.Second, false},
+		{"half life too small", ewma, 1, 0, true},
+		{"half life too large", ewma, 61, 0, true},
+		{"unknown mode", pb.AveragingMode(7), 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			halfLife, err := validateAveraging(limits, tt.mode, tt.halfLife, 30*time.Second)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("validateAveraging() error = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil || halfLife != tt.wantHalfLife {
+				t.Errorf("validateAveraging() = %v, %v, want %v", halfLife, err, tt.wantHalfLife)
+			}
+		})
+	}
+}
+
+func TestValidateResolution(t *testing.T) {
+	log, _ := logger.New(config.LoggerConfig{Level: "ERROR"})
+	newEngine := func(resolution, fast int) *metrics.Engine {
+		cfg := config.NewConfig()
+		cfg.Engine.Resolution = resolution
+		// Оператор включает опрос раз в 100 мс для отдельных подсистем
+		cfg.Resolution.LoadAvg, cfg.Resolution.CPU, cfg.Resolution.NetIface = fast, fast, fast
+		return metrics.NewEngine(cfg, log, loadavgFS{}, nil)
+	}
+	defaults, perSubsystem, everySecond := newEngine(0, 1000), newEngine(0, 100), newEngine(1, 100)
+
+	tests := []struct {
+		name     string
+		engine   *metrics.Engine
+		enabled  config.MetricsConfig
+		interval time.Duration
+		wantErr  bool
+	}{
+		{"default resolution", defaults, config.MetricsConfig{LoadAvg: true}, 100 * time.Millisecond, true},
+		{"default every second", defaults, config.MetricsConfig{LoadAvg: true, CPU: true}, time.Second, false},
+		{"fast subsystems", perSubsystem, config.MetricsConfig{LoadAvg: true, CPU: true, NetIface: true}, 100 * time.Millisecond, false},
+		{"df below a second", perSubsystem, config.MetricsConfig{CPU: true, Filesystem: true}, 500 * time.Millisecond, true},
+		{"disk not opted in", perSubsystem, config.MetricsConfig{CPU: true, Disk: true}, 100 * time.Millisecond, true},
+		{"df every second", perSubsystem, config.MetricsConfig{CPU: true, Filesystem: true}, time.Second, false},
+		{"below engine resolution", everySecond, config.MetricsConfig{LoadAvg: true}, 500 * time.Millisecond, true},
+		{"engine resolution", everySecond, config.MetricsConfig{LoadAvg: true}, time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResolution(tt.engine, tt.enabled, tt.interval)
+			if tt.wantErr != (status.Code(err) == codes.InvalidArgument) {
+				t.Errorf("validateResolution() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRetention(t *testing.T) {
+	log, _ := logger.New(config.LoggerConfig{Level: "ERROR"})
+	cfg := config.NewConfig()
+	cfg.Engine.Retention = 900
+	engine := metrics.NewEngine(cfg, log, loadavgFS{}, nil)
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		halfLife time.Duration
+		wantErr  bool
+	}{
+		{"window within retention", 15 * time.Minute, 0, false},
+		{"window beyond retention", time.Hour, 0, true},
+		{"half-life within retention", 15 * time.Second, 10 * time.Minute, false},
+		{"half-life beyond retention", 15 * time.Second, time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRetention(engine, tt.duration, tt.halfLife)
+			if tt.wantErr != (status.Code(err) == codes.InvalidArgument) {
+				t.Errorf("validateRetention() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
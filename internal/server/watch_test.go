@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shagrat164/system-monitoring-daemon/internal/config"
+	"github.com/shagrat164/system-monitoring-daemon/internal/logger"
+	"github.com/shagrat164/system-monitoring-daemon/internal/metrics"
+	pb "github.com/shagrat164/system-monitoring-daemon/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// loadavgFS - файловая система с одним /proc/loadavg.
+type loadavgFS struct{}
+
+func (loadavgFS) ReadFile(filename string) ([]byte, error) {
+	if filename == "/proc/loadavg" {
+		return []byte("0.50 0.40 0.30 1/100 12345"), nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (loadavgFS) ReadDir(string) ([]string, error) {
+	return nil, os.ErrNotExist
+}
+
+// newTestClient - поднимает сервер на bufconn с движком, собирающим только load average.
+func newTestClient(t *testing.T) pb.MonitoringClient {
+	t.Helper()
+
+	cfg := config.NewConfig()
+	cfg.Enabled = config.MetricsConfig{LoadAvg: true}
+	cfg.Resolution.LoadAvg = 100 // Миллисекундные окна тестов
+	log, _ := logger.New(cfg.Logger)
+
+	engine := metrics.NewEngine(cfg, log, loadavgFS{}, nil)
+	go engine.Run(t.Context())
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterMonitoringServer(srv, &monitoringServer{cfg: cfg, log: log, engine: engine})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() unexpected error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewMonitoringClient(conn)
+}
+
+func TestWatch(t *testing.T) {
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() unexpected error: %v", err)
+	}
+
+	// Оба запроса получают ответ сразу, не дожидаясь интервала в 60 секунд
+	for i, req := range []*pb.StatsRequest{
+		{Interval: 60, Duration: 60},
+		{Interval: 60, Duration: 120, Subsystems: []string{"load_avg"}},
+	} {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Send() unexpected error: %v", err)
+		}
+		stats, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() unexpected error: %v", err)
+		}
+
+		meta := stats.GetMeta()
+		if meta.GetSequence() != uint64(i+1) { //nolint:gosec
+			t.Errorf("Recv() sequence = %d, want %d", meta.GetSequence(), i+1)
+		}
+		window := meta.GetWindowEnd().AsTime().Sub(meta.GetWindowStart().AsTime())
+		if window != time.Duration(req.GetDuration())*time.Second {
+			t.Errorf("Recv() window = %v, want %ds", window, req.GetDuration())
+		}
+	}
+
+	// Некорректные настройки закрывают поток с InvalidArgument
+	if err := stream.Send(&pb.StatsRequest{Interval: 5, Duration: 7}); err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Recv() error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestQueryHistory(t *testing.T) {
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	// Движок делает первый замер сразу после запуска
+	var resp *pb.HistoryResponse
+	for {
+		var err error
+		resp, err = client.QueryHistory(ctx, &pb.HistoryRequest{Subsystem: "load_avg", Metric: "load_1min"})
+		if err != nil {
+			t.Fatalf("QueryHistory() unexpected error: %v", err)
+		}
+		if len(resp.GetSeries()) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	series := resp.GetSeries()[0]
+	if series.GetMetric() != "load_1min" || len(series.GetPoints()) == 0 || series.GetPoints()[0].GetValue() != 0.5 {
+		t.Errorf("QueryHistory() series = %v, want load_1min = 0.5", series)
+	}
+
+	if _, err := client.QueryHistory(ctx, &pb.HistoryRequest{Subsystem: "gpu"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("QueryHistory() error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestGetStatsEWMA(t *testing.T) {
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	// Окно в 60 секунд не копится: первый ответ приходит сразу после первого замера движка
+	stream, err := client.GetStats(ctx, &pb.StatsRequest{
+		Interval:  60,
+		Duration:  60,
+		Averaging: pb.AveragingMode_AVERAGING_EWMA,
+		HalfLife:  10,
+	})
+	if err != nil {
+		t.Fatalf("GetStats() unexpected error: %v", err)
+	}
+	stats, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() unexpected error: %v", err)
+	}
+	if stats.GetMeta().GetHalfLife() != 10 || stats.GetMeta().GetSequence() != 1 {
+		t.Errorf("Recv() meta = %v, want half_life 10 and sequence 1", stats.GetMeta())
+	}
+}
+
+func TestGetStatsWindow(t *testing.T) {
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	// Поток без своих коллекторов: значения и агрегаты - по одним замерам общего движка
+	stream, err := client.GetStats(ctx, &pb.StatsRequest{
+		Interval:   1,
+		Duration:   2,
+		Subsystems: []string{"load_avg"},
+		Aggregations: []*pb.AggregationRequest{
+			{Subsystem: "load_avg", Functions: []pb.Aggregation{pb.Aggregation_AGGREGATION_MEAN}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetStats() unexpected error: %v", err)
+	}
+	stats, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() unexpected error: %v", err)
+	}
+
+	if stats.GetLoadAverage_1Min() != 0.5 {
+		t.Errorf("Recv() load_1min = %v, want 0.5", stats.GetLoadAverage_1Min())
+	}
+	for _, a := range stats.GetAggregates() {
+		if a.GetMetric() == "load_1min" && a.GetValue() != stats.GetLoadAverage_1Min() {
+			t.Errorf("Recv() load_1min mean aggregate = %v, want %v", a.GetValue(), stats.GetLoadAverage_1Min())
+		}
+	}
+	if len(stats.GetAggregates()) == 0 {
+		t.Errorf("Recv() aggregates are empty")
+	}
+}
+
+func TestSubscribeWarmup(t *testing.T) {
+	for _, align := range []bool{false, true} {
+		t.Run(fmt.Sprintf("align=%v", align), func(t *testing.T) {
+			client := newTestClient(t)
+
+			ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+			defer cancel()
+
+			// Окно в 60 секунд молчит, но раз в секунду приходит прогресс накопления;
+			// выровненный поток тоже не отдаёт общие снимки до накопления окна
+			stream, err := client.Subscribe(ctx, &pb.StatsRequest{
+				Interval:   1,
+				Duration:   60,
+				Subsystems: []string{"load_avg"},
+				Heartbeat:  1,
+				Align:      align,
+			})
+			if err != nil {
+				t.Fatalf("Subscribe() unexpected error: %v", err)
+			}
+			update, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("Recv() unexpected error: %v", err)
+			}
+
+			warmup := update.GetStatus().GetWarmup()
+			if update.GetStatus().GetKind() != pb.StreamStatusKind_STREAM_WARMUP || len(warmup) != 1 ||
+				warmup[0].GetSubsystem() != "load_avg" || warmup[0].GetRequiredSeconds() != 60 {
+				t.Errorf("Recv() status = %v, want load_avg warm-up of 60s", update.GetStatus())
+			}
+			if update.GetSubsystem() != "" || update.GetPayload() != nil || update.GetMeta().GetSequence() != 1 {
+				t.Errorf("Recv() = %v, want status without data and sequence 1", update)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,30 +22,3011 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Способ усреднения основных значений
+type AveragingMode int32
+
+const (
+	AveragingMode_AVERAGING_WINDOW AveragingMode = 0 // Среднее по замерам скользящего окна M
+	AveragingMode_AVERAGING_EWMA   AveragingMode = 1 // Экспоненциально взвешенное среднее; поток отвечает сразу после первого замера
+)
+
+// Enum value maps for AveragingMode.
+var (
+	AveragingMode_name = map[int32]string{
+		0: "AVERAGING_WINDOW",
+		1: "AVERAGING_EWMA",
+	}
+	AveragingMode_value = map[string]int32{
+		"AVERAGING_WINDOW": 0,
+		"AVERAGING_EWMA":   1,
+	}
+)
+
+func (x AveragingMode) Enum() *AveragingMode {
+	p := new(AveragingMode)
+	*p = x
+	return p
+}
+
+func (x AveragingMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AveragingMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_monitoring_proto_enumTypes[0].Descriptor()
+}
+
+func (AveragingMode) Type() protoreflect.EnumType {
+	return &file_proto_monitoring_proto_enumTypes[0]
+}
+
+func (x AveragingMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AveragingMode.Descriptor instead.
+func (AveragingMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{0}
+}
+
+// Функция свёртки замеров окна
+type Aggregation int32
+
+const (
+	Aggregation_AGGREGATION_MEAN   Aggregation = 0
+	Aggregation_AGGREGATION_MIN    Aggregation = 1
+	Aggregation_AGGREGATION_MAX    Aggregation = 2
+	Aggregation_AGGREGATION_P50    Aggregation = 3
+	Aggregation_AGGREGATION_P95    Aggregation = 4
+	Aggregation_AGGREGATION_P99    Aggregation = 5
+	Aggregation_AGGREGATION_STDDEV Aggregation = 6
+	Aggregation_AGGREGATION_LAST   Aggregation = 7
+)
+
+// Enum value maps for Aggregation.
+var (
+	Aggregation_name = map[int32]string{
+		0: "AGGREGATION_MEAN",
+		1: "AGGREGATION_MIN",
+		2: "AGGREGATION_MAX",
+		3: "AGGREGATION_P50",
+		4: "AGGREGATION_P95",
+		5: "AGGREGATION_P99",
+		6: "AGGREGATION_STDDEV",
+		7: "AGGREGATION_LAST",
+	}
+	Aggregation_value = map[string]int32{
+		"AGGREGATION_MEAN":   0,
+		"AGGREGATION_MIN":    1,
+		"AGGREGATION_MAX":    2,
+		"AGGREGATION_P50":    3,
+		"AGGREGATION_P95":    4,
+		"AGGREGATION_P99":    5,
+		"AGGREGATION_STDDEV": 6,
+		"AGGREGATION_LAST":   7,
+	}
+)
+
+func (x Aggregation) Enum() *Aggregation {
+	p := new(Aggregation)
+	*p = x
+	return p
+}
+
+func (x Aggregation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Aggregation) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_monitoring_proto_enumTypes[1].Descriptor()
+}
+
+func (Aggregation) Type() protoreflect.EnumType {
+	return &file_proto_monitoring_proto_enumTypes[1]
+}
+
+func (x Aggregation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Aggregation.Descriptor instead.
+func (Aggregation) EnumDescriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{1}
+}
+
+type StreamStatusKind int32
+
+const (
+	StreamStatusKind_STREAM_WARMUP    StreamStatusKind = 0 // Окно ещё накапливается, данные будут позже
+	StreamStatusKind_STREAM_HEARTBEAT StreamStatusKind = 1 // Поток жив, очередной ответ ещё не готов
+)
+
+// Enum value maps for StreamStatusKind.
+var (
+	StreamStatusKind_name = map[int32]string{
+		0: "STREAM_WARMUP",
+		1: "STREAM_HEARTBEAT",
+	}
+	StreamStatusKind_value = map[string]int32{
+		"STREAM_WARMUP":    0,
+		"STREAM_HEARTBEAT": 1,
+	}
+)
+
+func (x StreamStatusKind) Enum() *StreamStatusKind {
+	p := new(StreamStatusKind)
+	*p = x
+	return p
+}
+
+func (x StreamStatusKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamStatusKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_monitoring_proto_enumTypes[2].Descriptor()
+}
+
+func (StreamStatusKind) Type() protoreflect.EnumType {
+	return &file_proto_monitoring_proto_enumTypes[2]
+}
+
+func (x StreamStatusKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamStatusKind.Descriptor instead.
+func (StreamStatusKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{2}
+}
+
+type EntityEventKind int32
+
+const (
+	EntityEventKind_ENTITY_ADDED   EntityEventKind = 0
+	EntityEventKind_ENTITY_REMOVED EntityEventKind = 1 // Сущность отсутствует дольше lifecycle.expire
+)
+
+// Enum value maps for EntityEventKind.
+var (
+	EntityEventKind_name = map[int32]string{
+		0: "ENTITY_ADDED",
+		1: "ENTITY_REMOVED",
+	}
+	EntityEventKind_value = map[string]int32{
+		"ENTITY_ADDED":   0,
+		"ENTITY_REMOVED": 1,
+	}
+)
+
+func (x EntityEventKind) Enum() *EntityEventKind {
+	p := new(EntityEventKind)
+	*p = x
+	return p
+}
+
+func (x EntityEventKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntityEventKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_monitoring_proto_enumTypes[3].Descriptor()
+}
+
+func (EntityEventKind) Type() protoreflect.EnumType {
+	return &file_proto_monitoring_proto_enumTypes[3]
+}
+
+func (x EntityEventKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntityEventKind.Descriptor instead.
+func (EntityEventKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{3}
+}
+
+// Итоговое состояние RAID-массива
+type RAIDHealth int32
+
+const (
+	RAIDHealth_RAID_HEALTH_UNKNOWN   RAIDHealth = 0
+	RAIDHealth_RAID_HEALTH_OK        RAIDHealth = 1
+	RAIDHealth_RAID_HEALTH_RESYNCING RAIDHealth = 2 // Идёт resync/check/reshape без потери избыточности
+	RAIDHealth_RAID_HEALTH_DEGRADED  RAIDHealth = 3 // Есть отсутствующие или сбойные диски
+	RAIDHealth_RAID_HEALTH_FAILED    RAIDHealth = 4 // Массив неактивен
+)
+
+// Enum value maps for RAIDHealth.
+var (
+	RAIDHealth_name = map[int32]string{
+		0: "RAID_HEALTH_UNKNOWN",
+		1: "RAID_HEALTH_OK",
+		2: "RAID_HEALTH_RESYNCING",
+		3: "RAID_HEALTH_DEGRADED",
+		4: "RAID_HEALTH_FAILED",
+	}
+	RAIDHealth_value = map[string]int32{
+		"RAID_HEALTH_UNKNOWN":   0,
+		"RAID_HEALTH_OK":        1,
+		"RAID_HEALTH_RESYNCING": 2,
+		"RAID_HEALTH_DEGRADED":  3,
+		"RAID_HEALTH_FAILED":    4,
+	}
+)
+
+func (x RAIDHealth) Enum() *RAIDHealth {
+	p := new(RAIDHealth)
+	*p = x
+	return p
+}
+
+func (x RAIDHealth) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RAIDHealth) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_monitoring_proto_enumTypes[4].Descriptor()
+}
+
+func (RAIDHealth) Type() protoreflect.EnumType {
+	return &file_proto_monitoring_proto_enumTypes[4]
+}
+
+func (x RAIDHealth) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RAIDHealth.Descriptor instead.
+func (RAIDHealth) EnumDescriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{4}
+}
+
+type DaemonStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DaemonStatsRequest) Reset() {
+	*x = DaemonStatsRequest{}
+	mi := &file_proto_monitoring_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonStatsRequest) ProtoMessage() {}
+
+func (x *DaemonStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonStatsRequest.ProtoReflect.Descriptor instead.
+func (*DaemonStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{0}
+}
+
 // Запрос на получение статистики
 type StatsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Interval     int32                  `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"`                            // Интервал обновления (N)
+	Duration     int32                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`                            // Период усреднения (M)
+	Subsystems   []string               `protobuf:"bytes,3,rep,name=subsystems,proto3" json:"subsystems,omitempty"`                         // Запрошенные подсистемы (пусто = все включённые в конфигурации)
+	Options      *SubsystemOptions      `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`                               // Фильтры подсистем
+	Aggregations []*AggregationRequest  `protobuf:"bytes,5,rep,name=aggregations,proto3" json:"aggregations,omitempty"`                     // Дополнительные агрегаты по подсистемам
+	Averaging    AveragingMode          `protobuf:"varint,6,opt,name=averaging,proto3,enum=proto.AveragingMode" json:"averaging,omitempty"` // Способ усреднения основных значений
+	HalfLife     int32                  `protobuf:"varint,7,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"`            // Период полураспада EWMA, сек (0 = M)
+	Heartbeat    int32                  `protobuf:"varint,8,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`                          // Период служебных сообщений GetStats и Subscribe о накоплении окна и heartbeat, сек (0 = не отправлять)
+	// Версия 2: N и M в миллисекундах для опроса чаще раза в секунду. Если задано, заменяет interval/duration
+	IntervalMs int32 `protobuf:"varint,9,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	DurationMs int32 `protobuf:"varint,10,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Снимки общего движка на границах времени, кратных N (:00, :05, :10 при N = 5 с). Подписчики
+	// с одинаковыми параметрами получают одинаковые снимки, вычисленные один раз
+	Align         bool `protobuf:"varint,11,opt,name=align,proto3" json:"align,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_proto_monitoring_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StatsRequest) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetSubsystems() []string {
+	if x != nil {
+		return x.Subsystems
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetOptions() *SubsystemOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetAggregations() []*AggregationRequest {
+	if x != nil {
+		return x.Aggregations
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetAveraging() AveragingMode {
+	if x != nil {
+		return x.Averaging
+	}
+	return AveragingMode_AVERAGING_WINDOW
+}
+
+func (x *StatsRequest) GetHalfLife() int32 {
+	if x != nil {
+		return x.HalfLife
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetHeartbeat() int32 {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetIntervalMs() int32 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetDurationMs() int32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetAlign() bool {
+	if x != nil {
+		return x.Align
+	}
+	return false
+}
+
+// Фильтры и ограничения по подсистемам
+type SubsystemOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disks         []string               `protobuf:"bytes,1,rep,name=disks,proto3" json:"disks,omitempty"`                    // Устройства в disk_stats (пусто = все)
+	Mountpoints   []string               `protobuf:"bytes,2,rep,name=mountpoints,proto3" json:"mountpoints,omitempty"`        // Точки монтирования в filesystem_stats (пусто = все)
+	Interfaces    []string               `protobuf:"bytes,3,rep,name=interfaces,proto3" json:"interfaces,omitempty"`          // Интерфейсы в net_iface_stats (пусто = все)
+	FdTopN        int32                  `protobuf:"varint,4,opt,name=fd_top_n,json=fdTopN,proto3" json:"fd_top_n,omitempty"` // Процессов в fd_stats (не больше top_n сервера; превысившие порог отдаются всегда)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubsystemOptions) Reset() {
+	*x = SubsystemOptions{}
+	mi := &file_proto_monitoring_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubsystemOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubsystemOptions) ProtoMessage() {}
+
+func (x *SubsystemOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubsystemOptions.ProtoReflect.Descriptor instead.
+func (*SubsystemOptions) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubsystemOptions) GetDisks() []string {
+	if x != nil {
+		return x.Disks
+	}
+	return nil
+}
+
+func (x *SubsystemOptions) GetMountpoints() []string {
+	if x != nil {
+		return x.Mountpoints
+	}
+	return nil
+}
+
+func (x *SubsystemOptions) GetInterfaces() []string {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *SubsystemOptions) GetFdTopN() int32 {
+	if x != nil {
+		return x.FdTopN
+	}
+	return 0
+}
+
+// Запрос разового снимка статистики
+type SnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Duration      int32                  `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"`                            // Период усреднения (M), сек
+	Subsystems    []string               `protobuf:"bytes,2,rep,name=subsystems,proto3" json:"subsystems,omitempty"`                         // Запрошенные подсистемы (пусто = все включённые в конфигурации)
+	Options       *SubsystemOptions      `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`                               // Фильтры подсистем
+	Aggregations  []*AggregationRequest  `protobuf:"bytes,4,rep,name=aggregations,proto3" json:"aggregations,omitempty"`                     // Дополнительные агрегаты по подсистемам
+	Averaging     AveragingMode          `protobuf:"varint,5,opt,name=averaging,proto3,enum=proto.AveragingMode" json:"averaging,omitempty"` // Способ усреднения основных значений
+	HalfLife      int32                  `protobuf:"varint,6,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"`            // Период полураспада EWMA, сек (0 = M)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	mi := &file_proto_monitoring_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SnapshotRequest) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *SnapshotRequest) GetSubsystems() []string {
+	if x != nil {
+		return x.Subsystems
+	}
+	return nil
+}
+
+func (x *SnapshotRequest) GetOptions() *SubsystemOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *SnapshotRequest) GetAggregations() []*AggregationRequest {
+	if x != nil {
+		return x.Aggregations
+	}
+	return nil
+}
+
+func (x *SnapshotRequest) GetAveraging() AveragingMode {
+	if x != nil {
+		return x.Averaging
+	}
+	return AveragingMode_AVERAGING_WINDOW
+}
+
+func (x *SnapshotRequest) GetHalfLife() int32 {
+	if x != nil {
+		return x.HalfLife
+	}
+	return 0
+}
+
+// Запрошенные агрегаты подсистемы
+type AggregationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subsystem     string                 `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"` // Подсистема (как в секции [metrics] конфигурации)
+	Functions     []Aggregation          `protobuf:"varint,2,rep,packed,name=functions,proto3,enum=proto.Aggregation" json:"functions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregationRequest) Reset() {
+	*x = AggregationRequest{}
+	mi := &file_proto_monitoring_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregationRequest) ProtoMessage() {}
+
+func (x *AggregationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregationRequest.ProtoReflect.Descriptor instead.
+func (*AggregationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AggregationRequest) GetSubsystem() string {
+	if x != nil {
+		return x.Subsystem
+	}
+	return ""
+}
+
+func (x *AggregationRequest) GetFunctions() []Aggregation {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+// Значение агрегата метрики за окно
+type Aggregate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subsystem     string                 `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	Metric        string                 `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"` // Метрика, как в QueryHistory
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`   // Устройство, интерфейс, точка монтирования
+	Function      Aggregation            `protobuf:"varint,4,opt,name=function,proto3,enum=proto.Aggregation" json:"function,omitempty"`
+	Value         float64                `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`
+	Samples       int32                  `protobuf:"varint,6,opt,name=samples,proto3" json:"samples,omitempty"` // Количество замеров в окне
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Aggregate) Reset() {
+	*x = Aggregate{}
+	mi := &file_proto_monitoring_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Aggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aggregate) ProtoMessage() {}
+
+func (x *Aggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aggregate.ProtoReflect.Descriptor instead.
+func (*Aggregate) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Aggregate) GetSubsystem() string {
+	if x != nil {
+		return x.Subsystem
+	}
+	return ""
+}
+
+func (x *Aggregate) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *Aggregate) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Aggregate) GetFunction() Aggregation {
+	if x != nil {
+		return x.Function
+	}
+	return Aggregation_AGGREGATION_MEAN
+}
+
+func (x *Aggregate) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Aggregate) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+// Снимок статистики за период
+type SnapshotResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Stats          *StatsResponse         `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	Duration       int32                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`                                    // Запрошенный период, сек
+	CoveredSeconds float64                `protobuf:"fixed64,3,opt,name=covered_seconds,json=coveredSeconds,proto3" json:"covered_seconds,omitempty"` // Фактически покрытая замерами часть периода (минимум по подсистемам)
+	Coverage       []*SubsystemCoverage   `protobuf:"bytes,4,rep,name=coverage,proto3" json:"coverage,omitempty"`                                     // Покрытие по подсистемам
+	Meta           *SnapshotMeta          `protobuf:"bytes,5,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SnapshotResponse) Reset() {
+	*x = SnapshotResponse{}
+	mi := &file_proto_monitoring_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotResponse) ProtoMessage() {}
+
+func (x *SnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SnapshotResponse) GetStats() *StatsResponse {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *SnapshotResponse) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *SnapshotResponse) GetCoveredSeconds() float64 {
+	if x != nil {
+		return x.CoveredSeconds
+	}
+	return 0
+}
+
+func (x *SnapshotResponse) GetCoverage() []*SubsystemCoverage {
+	if x != nil {
+		return x.Coverage
+	}
+	return nil
+}
+
+func (x *SnapshotResponse) GetMeta() *SnapshotMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+// Покрытие периода замерами подсистемы
+type SubsystemCoverage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Subsystem      string                 `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	Samples        int32                  `protobuf:"varint,2,opt,name=samples,proto3" json:"samples,omitempty"`                                      // Количество замеров
+	CoveredSeconds float64                `protobuf:"fixed64,3,opt,name=covered_seconds,json=coveredSeconds,proto3" json:"covered_seconds,omitempty"` // Покрытая часть периода, сек
+	Expected       int32                  `protobuf:"varint,4,opt,name=expected,proto3" json:"expected,omitempty"`                                    // Сколько замеров должно было быть: период по разрешению подсистемы с запуска демона
+	Ratio          float64                `protobuf:"fixed64,5,opt,name=ratio,proto3" json:"ratio,omitempty"`                                         // Доля полученных замеров samples / expected
+	LastError      string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                  // Последняя ошибка сбора подсистемы (пусто - ошибок не было)
+	LastErrorTime  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_error_time,json=lastErrorTime,proto3" json:"last_error_time,omitempty"`
+	Stale          bool                   `protobuf:"varint,8,opt,name=stale,proto3" json:"stale,omitempty"`                          // Доля замеров ниже coverage.min_ratio: значения подсистемы недостоверны
+	Restarts       int32                  `protobuf:"varint,9,opt,name=restarts,proto3" json:"restarts,omitempty"`                    // Перезапуски коллектора подсистемы после паники с момента старта демона
+	Degraded       bool                   `protobuf:"varint,10,opt,name=degraded,proto3" json:"degraded,omitempty"`                   // Коллектор паниковал и ещё не проработал supervisor.stable_after
+	LastPanic      string                 `protobuf:"bytes,11,opt,name=last_panic,json=lastPanic,proto3" json:"last_panic,omitempty"` // Значение последней паники
+	LastPanicTime  *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_panic_time,json=lastPanicTime,proto3" json:"last_panic_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubsystemCoverage) Reset() {
+	*x = SubsystemCoverage{}
+	mi := &file_proto_monitoring_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubsystemCoverage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubsystemCoverage) ProtoMessage() {}
+
+func (x *SubsystemCoverage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubsystemCoverage.ProtoReflect.Descriptor instead.
+func (*SubsystemCoverage) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubsystemCoverage) GetSubsystem() string {
+	if x != nil {
+		return x.Subsystem
+	}
+	return ""
+}
+
+func (x *SubsystemCoverage) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *SubsystemCoverage) GetCoveredSeconds() float64 {
+	if x != nil {
+		return x.CoveredSeconds
+	}
+	return 0
+}
+
+func (x *SubsystemCoverage) GetExpected() int32 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+func (x *SubsystemCoverage) GetRatio() float64 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+func (x *SubsystemCoverage) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *SubsystemCoverage) GetLastErrorTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastErrorTime
+	}
+	return nil
+}
+
+func (x *SubsystemCoverage) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *SubsystemCoverage) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *SubsystemCoverage) GetDegraded() bool {
+	if x != nil {
+		return x.Degraded
+	}
+	return false
+}
+
+func (x *SubsystemCoverage) GetLastPanic() string {
+	if x != nil {
+		return x.LastPanic
+	}
+	return ""
+}
+
+func (x *SubsystemCoverage) GetLastPanicTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastPanicTime
+	}
+	return nil
+}
+
+// Метаданные снимка: окно усреднения, источник и порядковый номер в потоке
+type SnapshotMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WindowStart   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"` // Начало окна [t-M, t]
+	WindowEnd     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`       // Конец окна
+	Sequence      uint64                 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`                         // Номер сообщения в потоке, начиная с 1 (0 для разовых запросов)
+	Hostname      string                 `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	BootId        string                 `protobuf:"bytes,5,opt,name=boot_id,json=bootId,proto3" json:"boot_id,omitempty"`        // /proc/sys/kernel/random/boot_id
+	Subsystems    []*SubsystemCoverage   `protobuf:"bytes,6,rep,name=subsystems,proto3" json:"subsystems,omitempty"`              // Количество усреднённых замеров по подсистемам
+	HalfLife      int32                  `protobuf:"varint,7,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"` // Период полураспада EWMA, сек (0 = среднее за окно)
+	Dropped       uint64                 `protobuf:"varint,8,opt,name=dropped,proto3" json:"dropped,omitempty"`                   // Сообщений потока, отброшенных из-за медленного чтения клиентом
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotMeta) Reset() {
+	*x = SnapshotMeta{}
+	mi := &file_proto_monitoring_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotMeta) ProtoMessage() {}
+
+func (x *SnapshotMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotMeta.ProtoReflect.Descriptor instead.
+func (*SnapshotMeta) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SnapshotMeta) GetWindowStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowStart
+	}
+	return nil
+}
+
+func (x *SnapshotMeta) GetWindowEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return nil
+}
+
+func (x *SnapshotMeta) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *SnapshotMeta) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *SnapshotMeta) GetBootId() string {
+	if x != nil {
+		return x.BootId
+	}
+	return ""
+}
+
+func (x *SnapshotMeta) GetSubsystems() []*SubsystemCoverage {
+	if x != nil {
+		return x.Subsystems
+	}
+	return nil
+}
+
+func (x *SnapshotMeta) GetHalfLife() int32 {
+	if x != nil {
+		return x.HalfLife
+	}
+	return 0
+}
+
+func (x *SnapshotMeta) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+// Запрос истории метрики
+type HistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subsystem     string                 `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"` // Подсистема (как в секции [metrics] конфигурации)
+	Metric        string                 `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"`       // Метрика, например load_1min или tps (пусто = все метрики подсистемы)
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`         // Устройство, интерфейс, точка монтирования (пусто = все)
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`           // Начало периода (по умолчанию to - 5 минут)
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`               // Конец периода (по умолчанию сейчас)
+	Step          int32                  `protobuf:"varint,6,opt,name=step,proto3" json:"step,omitempty"`          // Шаг усреднения, сек (0 = сырые точки)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
+	mi := &file_proto_monitoring_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryRequest) ProtoMessage() {}
+
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HistoryRequest) GetSubsystem() string {
+	if x != nil {
+		return x.Subsystem
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *HistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *HistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *HistoryRequest) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+type HistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        []*HistorySeries       `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	Resolution    int32                  `protobuf:"varint,2,opt,name=resolution,proto3" json:"resolution,omitempty"` // Разрешение точек ответа (шаг свёртки или уровня хранения), сек
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryResponse) Reset() {
+	*x = HistoryResponse{}
+	mi := &file_proto_monitoring_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryResponse) ProtoMessage() {}
+
+func (x *HistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryResponse.ProtoReflect.Descriptor instead.
+func (*HistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HistoryResponse) GetSeries() []*HistorySeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+func (x *HistoryResponse) GetResolution() int32 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+// Временной ряд метрики
+type HistorySeries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subsystem     string                 `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	Metric        string                 `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"`
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Points        []*HistoryPoint        `protobuf:"bytes,4,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistorySeries) Reset() {
+	*x = HistorySeries{}
+	mi := &file_proto_monitoring_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistorySeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistorySeries) ProtoMessage() {}
+
+func (x *HistorySeries) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistorySeries.ProtoReflect.Descriptor instead.
+func (*HistorySeries) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HistorySeries) GetSubsystem() string {
+	if x != nil {
+		return x.Subsystem
+	}
+	return ""
+}
+
+func (x *HistorySeries) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *HistorySeries) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *HistorySeries) GetPoints() []*HistoryPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+// Свёртка значений метрики за интервал, начинающийся в time
+type HistoryPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"` // Среднее
+	Min           float64                `protobuf:"fixed64,3,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,4,opt,name=max,proto3" json:"max,omitempty"`
+	Count         int32                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"` // Количество замеров в интервале
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryPoint) Reset() {
+	*x = HistoryPoint{}
+	mi := &file_proto_monitoring_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryPoint) ProtoMessage() {}
+
+func (x *HistoryPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryPoint.ProtoReflect.Descriptor instead.
+func (*HistoryPoint) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HistoryPoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *HistoryPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Обновление одной подсистемы. Отсутствие обновления означает "нет данных", а не нули
+type SubsystemUpdate struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Time       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`           // Время формирования обновления
+	Subsystem  string                 `protobuf:"bytes,2,opt,name=subsystem,proto3" json:"subsystem,omitempty"` // Имя подсистемы (как в секции [metrics] конфигурации)
+	Meta       *SnapshotMeta          `protobuf:"bytes,3,opt,name=meta,proto3" json:"meta,omitempty"`
+	Aggregates []*Aggregate           `protobuf:"bytes,4,rep,name=aggregates,proto3" json:"aggregates,omitempty"` // Запрошенные агрегаты подсистемы
+	Events     []*EntityEvent         `protobuf:"bytes,5,rep,name=events,proto3" json:"events,omitempty"`         // Появившиеся и удалённые сущности подсистемы
+	Status     *StreamStatus          `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`         // Служебное сообщение без данных: subsystem и payload пусты
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*SubsystemUpdate_LoadAverage
+	//	*SubsystemUpdate_Cpu
+	//	*SubsystemUpdate_Disk
+	//	*SubsystemUpdate_Filesystem
+	//	*SubsystemUpdate_Fd
+	//	*SubsystemUpdate_NetProto
+	//	*SubsystemUpdate_NetIface
+	//	*SubsystemUpdate_Raid
+	//	*SubsystemUpdate_BlockDevices
+	//	*SubsystemUpdate_Daemon
+	Payload       isSubsystemUpdate_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubsystemUpdate) Reset() {
+	*x = SubsystemUpdate{}
+	mi := &file_proto_monitoring_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubsystemUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubsystemUpdate) ProtoMessage() {}
+
+func (x *SubsystemUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubsystemUpdate.ProtoReflect.Descriptor instead.
+func (*SubsystemUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SubsystemUpdate) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetSubsystem() string {
+	if x != nil {
+		return x.Subsystem
+	}
+	return ""
+}
+
+func (x *SubsystemUpdate) GetMeta() *SnapshotMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetAggregates() []*Aggregate {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetEvents() []*EntityEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetStatus() *StreamStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetPayload() isSubsystemUpdate_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetLoadAverage() *LoadAverage {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_LoadAverage); ok {
+			return x.LoadAverage
+		}
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetCpu() *CPUUsage {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_Cpu); ok {
+			return x.Cpu
+		}
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetDisk() *DiskStatsList {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_Disk); ok {
+			return x.Disk
+		}
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetFilesystem() *FilesystemStatsList {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_Filesystem); ok {
+			return x.Filesystem
+		}
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetFd() *FDStats {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_Fd); ok {
+			return x.Fd
+		}
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetNetProto() *NetProtoStats {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_NetProto); ok {
+			return x.NetProto
+		}
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetNetIface() *NetIfaceStatsList {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_NetIface); ok {
+			return x.NetIface
+		}
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetRaid() *RAIDArrayList {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_Raid); ok {
+			return x.Raid
+		}
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetBlockDevices() *BlockDeviceList {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_BlockDevices); ok {
+			return x.BlockDevices
+		}
+	}
+	return nil
+}
+
+func (x *SubsystemUpdate) GetDaemon() *DaemonStats {
+	if x != nil {
+		if x, ok := x.Payload.(*SubsystemUpdate_Daemon); ok {
+			return x.Daemon
+		}
+	}
+	return nil
+}
+
+type isSubsystemUpdate_Payload interface {
+	isSubsystemUpdate_Payload()
+}
+
+type SubsystemUpdate_LoadAverage struct {
+	LoadAverage *LoadAverage `protobuf:"bytes,10,opt,name=load_average,json=loadAverage,proto3,oneof"`
+}
+
+type SubsystemUpdate_Cpu struct {
+	Cpu *CPUUsage `protobuf:"bytes,11,opt,name=cpu,proto3,oneof"`
+}
+
+type SubsystemUpdate_Disk struct {
+	Disk *DiskStatsList `protobuf:"bytes,12,opt,name=disk,proto3,oneof"`
+}
+
+type SubsystemUpdate_Filesystem struct {
+	Filesystem *FilesystemStatsList `protobuf:"bytes,13,opt,name=filesystem,proto3,oneof"`
+}
+
+type SubsystemUpdate_Fd struct {
+	Fd *FDStats `protobuf:"bytes,14,opt,name=fd,proto3,oneof"`
+}
+
+type SubsystemUpdate_NetProto struct {
+	NetProto *NetProtoStats `protobuf:"bytes,15,opt,name=net_proto,json=netProto,proto3,oneof"`
+}
+
+type SubsystemUpdate_NetIface struct {
+	NetIface *NetIfaceStatsList `protobuf:"bytes,16,opt,name=net_iface,json=netIface,proto3,oneof"`
+}
+
+type SubsystemUpdate_Raid struct {
+	Raid *RAIDArrayList `protobuf:"bytes,17,opt,name=raid,proto3,oneof"`
+}
+
+type SubsystemUpdate_BlockDevices struct {
+	BlockDevices *BlockDeviceList `protobuf:"bytes,18,opt,name=block_devices,json=blockDevices,proto3,oneof"`
+}
+
+type SubsystemUpdate_Daemon struct {
+	Daemon *DaemonStats `protobuf:"bytes,19,opt,name=daemon,proto3,oneof"`
+}
+
+func (*SubsystemUpdate_LoadAverage) isSubsystemUpdate_Payload() {}
+
+func (*SubsystemUpdate_Cpu) isSubsystemUpdate_Payload() {}
+
+func (*SubsystemUpdate_Disk) isSubsystemUpdate_Payload() {}
+
+func (*SubsystemUpdate_Filesystem) isSubsystemUpdate_Payload() {}
+
+func (*SubsystemUpdate_Fd) isSubsystemUpdate_Payload() {}
+
+func (*SubsystemUpdate_NetProto) isSubsystemUpdate_Payload() {}
+
+func (*SubsystemUpdate_NetIface) isSubsystemUpdate_Payload() {}
+
+func (*SubsystemUpdate_Raid) isSubsystemUpdate_Payload() {}
+
+func (*SubsystemUpdate_BlockDevices) isSubsystemUpdate_Payload() {}
+
+func (*SubsystemUpdate_Daemon) isSubsystemUpdate_Payload() {}
+
+type LoadAverage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Load_1Min     float64                `protobuf:"fixed64,1,opt,name=load_1min,json=load1min,proto3" json:"load_1min,omitempty"`
+	Load_5Min     float64                `protobuf:"fixed64,2,opt,name=load_5min,json=load5min,proto3" json:"load_5min,omitempty"`
+	Load_15Min    float64                `protobuf:"fixed64,3,opt,name=load_15min,json=load15min,proto3" json:"load_15min,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadAverage) Reset() {
+	*x = LoadAverage{}
+	mi := &file_proto_monitoring_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadAverage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadAverage) ProtoMessage() {}
+
+func (x *LoadAverage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadAverage.ProtoReflect.Descriptor instead.
+func (*LoadAverage) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LoadAverage) GetLoad_1Min() float64 {
+	if x != nil {
+		return x.Load_1Min
+	}
+	return 0
+}
+
+func (x *LoadAverage) GetLoad_5Min() float64 {
+	if x != nil {
+		return x.Load_5Min
+	}
+	return 0
+}
+
+func (x *LoadAverage) GetLoad_15Min() float64 {
+	if x != nil {
+		return x.Load_15Min
+	}
+	return 0
+}
+
+type CPUUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          float64                `protobuf:"fixed64,1,opt,name=user,proto3" json:"user,omitempty"`     // Процент времени CPU в user mode
+	System        float64                `protobuf:"fixed64,2,opt,name=system,proto3" json:"system,omitempty"` // Процент времени CPU в system mode
+	Idle          float64                `protobuf:"fixed64,3,opt,name=idle,proto3" json:"idle,omitempty"`     // Процент времени CPU в idle
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CPUUsage) Reset() {
+	*x = CPUUsage{}
+	mi := &file_proto_monitoring_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CPUUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CPUUsage) ProtoMessage() {}
+
+func (x *CPUUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CPUUsage.ProtoReflect.Descriptor instead.
+func (*CPUUsage) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CPUUsage) GetUser() float64 {
+	if x != nil {
+		return x.User
+	}
+	return 0
+}
+
+func (x *CPUUsage) GetSystem() float64 {
+	if x != nil {
+		return x.System
+	}
+	return 0
+}
+
+func (x *CPUUsage) GetIdle() float64 {
+	if x != nil {
+		return x.Idle
+	}
+	return 0
+}
+
+type DiskStatsList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disks         []*DiskStats           `protobuf:"bytes,1,rep,name=disks,proto3" json:"disks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskStatsList) Reset() {
+	*x = DiskStatsList{}
+	mi := &file_proto_monitoring_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskStatsList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskStatsList) ProtoMessage() {}
+
+func (x *DiskStatsList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskStatsList.ProtoReflect.Descriptor instead.
+func (*DiskStatsList) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DiskStatsList) GetDisks() []*DiskStats {
+	if x != nil {
+		return x.Disks
+	}
+	return nil
+}
+
+type FilesystemStatsList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filesystems   []*FilesystemStats     `protobuf:"bytes,1,rep,name=filesystems,proto3" json:"filesystems,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilesystemStatsList) Reset() {
+	*x = FilesystemStatsList{}
+	mi := &file_proto_monitoring_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilesystemStatsList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilesystemStatsList) ProtoMessage() {}
+
+func (x *FilesystemStatsList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilesystemStatsList.ProtoReflect.Descriptor instead.
+func (*FilesystemStatsList) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FilesystemStatsList) GetFilesystems() []*FilesystemStats {
+	if x != nil {
+		return x.Filesystems
+	}
+	return nil
+}
+
+type NetIfaceStatsList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interfaces    []*NetIfaceStats       `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetIfaceStatsList) Reset() {
+	*x = NetIfaceStatsList{}
+	mi := &file_proto_monitoring_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetIfaceStatsList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetIfaceStatsList) ProtoMessage() {}
+
+func (x *NetIfaceStatsList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetIfaceStatsList.ProtoReflect.Descriptor instead.
+func (*NetIfaceStatsList) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NetIfaceStatsList) GetInterfaces() []*NetIfaceStats {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+type RAIDArrayList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Arrays        []*RAIDArray           `protobuf:"bytes,1,rep,name=arrays,proto3" json:"arrays,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RAIDArrayList) Reset() {
+	*x = RAIDArrayList{}
+	mi := &file_proto_monitoring_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RAIDArrayList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RAIDArrayList) ProtoMessage() {}
+
+func (x *RAIDArrayList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RAIDArrayList.ProtoReflect.Descriptor instead.
+func (*RAIDArrayList) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RAIDArrayList) GetArrays() []*RAIDArray {
+	if x != nil {
+		return x.Arrays
+	}
+	return nil
+}
+
+type BlockDeviceList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*BlockDevice         `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockDeviceList) Reset() {
+	*x = BlockDeviceList{}
+	mi := &file_proto_monitoring_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockDeviceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockDeviceList) ProtoMessage() {}
+
+func (x *BlockDeviceList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockDeviceList.ProtoReflect.Descriptor instead.
+func (*BlockDeviceList) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BlockDeviceList) GetDevices() []*BlockDevice {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+// Ответ со статистикой
+type StatsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	LoadAverage_1Min  float64                `protobuf:"fixed64,1,opt,name=load_average_1min,json=loadAverage1min,proto3" json:"load_average_1min,omitempty"`
+	LoadAverage_5Min  float64                `protobuf:"fixed64,2,opt,name=load_average_5min,json=loadAverage5min,proto3" json:"load_average_5min,omitempty"`
+	LoadAverage_15Min float64                `protobuf:"fixed64,3,opt,name=load_average_15min,json=loadAverage15min,proto3" json:"load_average_15min,omitempty"`
+	CpuUser           float64                `protobuf:"fixed64,4,opt,name=cpu_user,json=cpuUser,proto3" json:"cpu_user,omitempty"`       // Процент времени CPU в user mode
+	CpuSystem         float64                `protobuf:"fixed64,5,opt,name=cpu_system,json=cpuSystem,proto3" json:"cpu_system,omitempty"` // Процент времени CPU в system mode
+	CpuIdle           float64                `protobuf:"fixed64,6,opt,name=cpu_idle,json=cpuIdle,proto3" json:"cpu_idle,omitempty"`       // Процент времени CPU в idle
+	DiskStats         []*DiskStats           `protobuf:"bytes,7,rep,name=disk_stats,json=diskStats,proto3" json:"disk_stats,omitempty"`
+	FilesystemStats   []*FilesystemStats     `protobuf:"bytes,8,rep,name=filesystem_stats,json=filesystemStats,proto3" json:"filesystem_stats,omitempty"`
+	FdStats           *FDStats               `protobuf:"bytes,9,opt,name=fd_stats,json=fdStats,proto3" json:"fd_stats,omitempty"`
+	NetProtoStats     *NetProtoStats         `protobuf:"bytes,10,opt,name=net_proto_stats,json=netProtoStats,proto3" json:"net_proto_stats,omitempty"`
+	NetIfaceStats     []*NetIfaceStats       `protobuf:"bytes,11,rep,name=net_iface_stats,json=netIfaceStats,proto3" json:"net_iface_stats,omitempty"`
+	RaidArrays        []*RAIDArray           `protobuf:"bytes,12,rep,name=raid_arrays,json=raidArrays,proto3" json:"raid_arrays,omitempty"`
+	BlockDevices      []*BlockDevice         `protobuf:"bytes,13,rep,name=block_devices,json=blockDevices,proto3" json:"block_devices,omitempty"`
+	Meta              *SnapshotMeta          `protobuf:"bytes,14,opt,name=meta,proto3" json:"meta,omitempty"`
+	Aggregates        []*Aggregate           `protobuf:"bytes,15,rep,name=aggregates,proto3" json:"aggregates,omitempty"`                      // Запрошенные агрегаты (основные поля - среднее)
+	Events            []*EntityEvent         `protobuf:"bytes,16,rep,name=events,proto3" json:"events,omitempty"`                              // Появившиеся и удалённые сущности с прошлого ответа
+	Status            *StreamStatus          `protobuf:"bytes,17,opt,name=status,proto3" json:"status,omitempty"`                              // Служебное сообщение без данных (только при heartbeat в запросе)
+	DaemonStats       *DaemonStats           `protobuf:"bytes,18,opt,name=daemon_stats,json=daemonStats,proto3" json:"daemon_stats,omitempty"` // Внутренние метрики демона
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_proto_monitoring_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StatsResponse) GetLoadAverage_1Min() float64 {
+	if x != nil {
+		return x.LoadAverage_1Min
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetLoadAverage_5Min() float64 {
+	if x != nil {
+		return x.LoadAverage_5Min
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetLoadAverage_15Min() float64 {
+	if x != nil {
+		return x.LoadAverage_15Min
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetCpuUser() float64 {
+	if x != nil {
+		return x.CpuUser
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetCpuSystem() float64 {
+	if x != nil {
+		return x.CpuSystem
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetCpuIdle() float64 {
+	if x != nil {
+		return x.CpuIdle
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetDiskStats() []*DiskStats {
+	if x != nil {
+		return x.DiskStats
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetFilesystemStats() []*FilesystemStats {
+	if x != nil {
+		return x.FilesystemStats
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetFdStats() *FDStats {
+	if x != nil {
+		return x.FdStats
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetNetProtoStats() *NetProtoStats {
+	if x != nil {
+		return x.NetProtoStats
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetNetIfaceStats() []*NetIfaceStats {
+	if x != nil {
+		return x.NetIfaceStats
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetRaidArrays() []*RAIDArray {
+	if x != nil {
+		return x.RaidArrays
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetBlockDevices() []*BlockDevice {
+	if x != nil {
+		return x.BlockDevices
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetMeta() *SnapshotMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetAggregates() []*Aggregate {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetEvents() []*EntityEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetStatus() *StreamStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetDaemonStats() *DaemonStats {
+	if x != nil {
+		return x.DaemonStats
+	}
+	return nil
+}
+
+// Состояние потока между ответами с данными
+type StreamStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          StreamStatusKind       `protobuf:"varint,1,opt,name=kind,proto3,enum=proto.StreamStatusKind" json:"kind,omitempty"`
+	Warmup        []*WarmupProgress      `protobuf:"bytes,2,rep,name=warmup,proto3" json:"warmup,omitempty"` // Подсистемы, ещё не отправившие первый ответ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamStatus) Reset() {
+	*x = StreamStatus{}
+	mi := &file_proto_monitoring_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamStatus) ProtoMessage() {}
+
+func (x *StreamStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamStatus.ProtoReflect.Descriptor instead.
+func (*StreamStatus) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StreamStatus) GetKind() StreamStatusKind {
+	if x != nil {
+		return x.Kind
+	}
+	return StreamStatusKind_STREAM_WARMUP
+}
+
+func (x *StreamStatus) GetWarmup() []*WarmupProgress {
+	if x != nil {
+		return x.Warmup
+	}
+	return nil
+}
+
+// Прогресс накопления окна подсистемы до первого ответа
+type WarmupProgress struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Subsystem          string                 `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	AccumulatedSeconds float64                `protobuf:"fixed64,2,opt,name=accumulated_seconds,json=accumulatedSeconds,proto3" json:"accumulated_seconds,omitempty"` // Сколько секунд замеров уже накоплено
+	RequiredSeconds    float64                `protobuf:"fixed64,3,opt,name=required_seconds,json=requiredSeconds,proto3" json:"required_seconds,omitempty"`          // Сколько нужно до первого ответа
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *WarmupProgress) Reset() {
+	*x = WarmupProgress{}
+	mi := &file_proto_monitoring_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmupProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmupProgress) ProtoMessage() {}
+
+func (x *WarmupProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmupProgress.ProtoReflect.Descriptor instead.
+func (*WarmupProgress) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WarmupProgress) GetSubsystem() string {
+	if x != nil {
+		return x.Subsystem
+	}
+	return ""
+}
+
+func (x *WarmupProgress) GetAccumulatedSeconds() float64 {
+	if x != nil {
+		return x.AccumulatedSeconds
+	}
+	return 0
+}
+
+func (x *WarmupProgress) GetRequiredSeconds() float64 {
+	if x != nil {
+		return x.RequiredSeconds
+	}
+	return 0
+}
+
+// Появление или исчезновение сущности подсистемы: диска, точки монтирования, интерфейса, массива
+type EntityEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Subsystem     string                 `protobuf:"bytes,2,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          EntityEventKind        `protobuf:"varint,4,opt,name=kind,proto3,enum=proto.EntityEventKind" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityEvent) Reset() {
+	*x = EntityEvent{}
+	mi := &file_proto_monitoring_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityEvent) ProtoMessage() {}
+
+func (x *EntityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityEvent.ProtoReflect.Descriptor instead.
+func (*EntityEvent) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EntityEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *EntityEvent) GetSubsystem() string {
+	if x != nil {
+		return x.Subsystem
+	}
+	return ""
+}
+
+func (x *EntityEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EntityEvent) GetKind() EntityEventKind {
+	if x != nil {
+		return x.Kind
+	}
+	return EntityEventKind_ENTITY_ADDED
+}
+
+type DiskStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Tps           float64                `protobuf:"fixed64,2,opt,name=tps,proto3" json:"tps,omitempty"`                        // Операций ввода-вывода в секунду (по разности счётчиков за период M)
+	KbRead        float64                `protobuf:"fixed64,3,opt,name=kb_read,json=kbRead,proto3" json:"kb_read,omitempty"`    // Чтение, KB/s
+	KbWrite       float64                `protobuf:"fixed64,4,opt,name=kb_write,json=kbWrite,proto3" json:"kb_write,omitempty"` // Запись, KB/s
+	KbTotal       float64                `protobuf:"fixed64,5,opt,name=kb_total,json=kbTotal,proto3" json:"kb_total,omitempty"` // Сумма чтения и записи
+	Mountpoints   []string               `protobuf:"bytes,6,rep,name=mountpoints,proto3" json:"mountpoints,omitempty"`          // Обслуживаемые точки монтирования (при включённом block_devices)
+	Partial       bool                   `protobuf:"varint,7,opt,name=partial,proto3" json:"partial,omitempty"`                 // Устройство появилось внутри периода: скорость за неполное окно
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskStats) Reset() {
+	*x = DiskStats{}
+	mi := &file_proto_monitoring_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskStats) ProtoMessage() {}
+
+func (x *DiskStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskStats.ProtoReflect.Descriptor instead.
+func (*DiskStats) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DiskStats) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *DiskStats) GetTps() float64 {
+	if x != nil {
+		return x.Tps
+	}
+	return 0
+}
+
+func (x *DiskStats) GetKbRead() float64 {
+	if x != nil {
+		return x.KbRead
+	}
+	return 0
+}
+
+func (x *DiskStats) GetKbWrite() float64 {
+	if x != nil {
+		return x.KbWrite
+	}
+	return 0
+}
+
+func (x *DiskStats) GetKbTotal() float64 {
+	if x != nil {
+		return x.KbTotal
+	}
+	return 0
+}
+
+func (x *DiskStats) GetMountpoints() []string {
+	if x != nil {
+		return x.Mountpoints
+	}
+	return nil
+}
+
+func (x *DiskStats) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+type FilesystemStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Filesystem     string                 `protobuf:"bytes,1,opt,name=filesystem,proto3" json:"filesystem,omitempty"`
+	Mountpoint     string                 `protobuf:"bytes,2,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
+	UsedMb         float64                `protobuf:"fixed64,3,opt,name=used_mb,json=usedMb,proto3" json:"used_mb,omitempty"`
+	UsedPercent    float64                `protobuf:"fixed64,4,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
+	InodesUsed     float64                `protobuf:"fixed64,5,opt,name=inodes_used,json=inodesUsed,proto3" json:"inodes_used,omitempty"`
+	InodesPercent  float64                `protobuf:"fixed64,6,opt,name=inodes_percent,json=inodesPercent,proto3" json:"inodes_percent,omitempty"`
+	BackingDevices []string               `protobuf:"bytes,7,rep,name=backing_devices,json=backingDevices,proto3" json:"backing_devices,omitempty"` // Устройства под ФС (при включённом block_devices)
+	Partial        bool                   `protobuf:"varint,8,opt,name=partial,proto3" json:"partial,omitempty"`                                    // Смонтирована внутри периода: среднее за неполное окно
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FilesystemStats) Reset() {
+	*x = FilesystemStats{}
+	mi := &file_proto_monitoring_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilesystemStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilesystemStats) ProtoMessage() {}
+
+func (x *FilesystemStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilesystemStats.ProtoReflect.Descriptor instead.
+func (*FilesystemStats) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FilesystemStats) GetFilesystem() string {
+	if x != nil {
+		return x.Filesystem
+	}
+	return ""
+}
+
+func (x *FilesystemStats) GetMountpoint() string {
+	if x != nil {
+		return x.Mountpoint
+	}
+	return ""
+}
+
+func (x *FilesystemStats) GetUsedMb() float64 {
+	if x != nil {
+		return x.UsedMb
+	}
+	return 0
+}
+
+func (x *FilesystemStats) GetUsedPercent() float64 {
+	if x != nil {
+		return x.UsedPercent
+	}
+	return 0
+}
+
+func (x *FilesystemStats) GetInodesUsed() float64 {
+	if x != nil {
+		return x.InodesUsed
+	}
+	return 0
+}
+
+func (x *FilesystemStats) GetInodesPercent() float64 {
+	if x != nil {
+		return x.InodesPercent
+	}
+	return 0
+}
+
+func (x *FilesystemStats) GetBackingDevices() []string {
+	if x != nil {
+		return x.BackingDevices
+	}
+	return nil
+}
+
+func (x *FilesystemStats) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+// Статистика файловых дескрипторов
+type FDStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allocated     float64                `protobuf:"fixed64,1,opt,name=allocated,proto3" json:"allocated,omitempty"`                        // Выделено дескрипторов в системе
+	Max           float64                `protobuf:"fixed64,2,opt,name=max,proto3" json:"max,omitempty"`                                    // Системный лимит дескрипторов (file-max)
+	UsedPercent   float64                `protobuf:"fixed64,3,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"` // Процент использования системного лимита
+	Processes     []*ProcessFDStats      `protobuf:"bytes,4,rep,name=processes,proto3" json:"processes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FDStats) Reset() {
+	*x = FDStats{}
+	mi := &file_proto_monitoring_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FDStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FDStats) ProtoMessage() {}
+
+func (x *FDStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FDStats.ProtoReflect.Descriptor instead.
+func (*FDStats) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *FDStats) GetAllocated() float64 {
+	if x != nil {
+		return x.Allocated
+	}
+	return 0
+}
+
+func (x *FDStats) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *FDStats) GetUsedPercent() float64 {
+	if x != nil {
+		return x.UsedPercent
+	}
+	return 0
+}
+
+func (x *FDStats) GetProcesses() []*ProcessFDStats {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+type ProcessFDStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	OpenFds       float64                `protobuf:"fixed64,3,opt,name=open_fds,json=openFds,proto3" json:"open_fds,omitempty"`                  // Открытые дескрипторы
+	Limit         float64                `protobuf:"fixed64,4,opt,name=limit,proto3" json:"limit,omitempty"`                                     // Мягкий лимит RLIMIT_NOFILE (0 = unlimited)
+	UsedPercent   float64                `protobuf:"fixed64,5,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`      // Процент от лимита
+	OverThreshold bool                   `protobuf:"varint,6,opt,name=over_threshold,json=overThreshold,proto3" json:"over_threshold,omitempty"` // Превышен порог из конфигурации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessFDStats) Reset() {
+	*x = ProcessFDStats{}
+	mi := &file_proto_monitoring_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessFDStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessFDStats) ProtoMessage() {}
+
+func (x *ProcessFDStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessFDStats.ProtoReflect.Descriptor instead.
+func (*ProcessFDStats) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ProcessFDStats) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProcessFDStats) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ProcessFDStats) GetOpenFds() float64 {
+	if x != nil {
+		return x.OpenFds
+	}
+	return 0
+}
+
+func (x *ProcessFDStats) GetLimit() float64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ProcessFDStats) GetUsedPercent() float64 {
+	if x != nil {
+		return x.UsedPercent
+	}
+	return 0
+}
+
+func (x *ProcessFDStats) GetOverThreshold() bool {
+	if x != nil {
+		return x.OverThreshold
+	}
+	return false
+}
+
+// Счётчики здоровья сетевых протоколов (/proc/net/snmp, /proc/net/netstat)
+type NetProtoStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counters      []*ProtoCounter        `protobuf:"bytes,1,rep,name=counters,proto3" json:"counters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetProtoStats) Reset() {
+	*x = NetProtoStats{}
+	mi := &file_proto_monitoring_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetProtoStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetProtoStats) ProtoMessage() {}
+
+func (x *NetProtoStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetProtoStats.ProtoReflect.Descriptor instead.
+func (*NetProtoStats) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *NetProtoStats) GetCounters() []*ProtoCounter {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+type ProtoCounter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Protocol      string                 `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"` // Tcp, TcpExt, Udp
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`         // Имя счётчика, например RetransSegs
+	Rate          float64                `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`       // Событий в секунду за период M
+	Total         float64                `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`     // Значение счётчика на конец периода
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProtoCounter) Reset() {
+	*x = ProtoCounter{}
+	mi := &file_proto_monitoring_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoCounter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoCounter) ProtoMessage() {}
+
+func (x *ProtoCounter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoCounter.ProtoReflect.Descriptor instead.
+func (*ProtoCounter) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ProtoCounter) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ProtoCounter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProtoCounter) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ProtoCounter) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// Пропускная способность и метаданные линка сетевого интерфейса
+type NetIfaceStats struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RxBytesPerSec        float64                `protobuf:"fixed64,2,opt,name=rx_bytes_per_sec,json=rxBytesPerSec,proto3" json:"rx_bytes_per_sec,omitempty"`
+	TxBytesPerSec        float64                `protobuf:"fixed64,3,opt,name=tx_bytes_per_sec,json=txBytesPerSec,proto3" json:"tx_bytes_per_sec,omitempty"`
+	SpeedMbps            float64                `protobuf:"fixed64,4,opt,name=speed_mbps,json=speedMbps,proto3" json:"speed_mbps,omitempty"` // Согласованная скорость (0 = неизвестна)
+	Duplex               string                 `protobuf:"bytes,5,opt,name=duplex,proto3" json:"duplex,omitempty"`                          // full, half, unknown
+	Mtu                  float64                `protobuf:"fixed64,6,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	Operstate            string                 `protobuf:"bytes,7,opt,name=operstate,proto3" json:"operstate,omitempty"`                                                       // up, down, unknown...
+	CarrierChanges       float64                `protobuf:"fixed64,8,opt,name=carrier_changes,json=carrierChanges,proto3" json:"carrier_changes,omitempty"`                     // Смен carrier с момента загрузки
+	CarrierChangesPeriod float64                `protobuf:"fixed64,9,opt,name=carrier_changes_period,json=carrierChangesPeriod,proto3" json:"carrier_changes_period,omitempty"` // Смен carrier за период M
+	UtilizationPercent   float64                `protobuf:"fixed64,10,opt,name=utilization_percent,json=utilizationPercent,proto3" json:"utilization_percent,omitempty"`        // Загрузка в % от скорости линка
+	Partial              bool                   `protobuf:"varint,11,opt,name=partial,proto3" json:"partial,omitempty"`                                                         // Интерфейс появился внутри периода: скорость за неполное окно
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *NetIfaceStats) Reset() {
+	*x = NetIfaceStats{}
+	mi := &file_proto_monitoring_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetIfaceStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetIfaceStats) ProtoMessage() {}
+
+func (x *NetIfaceStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetIfaceStats.ProtoReflect.Descriptor instead.
+func (*NetIfaceStats) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *NetIfaceStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetIfaceStats) GetRxBytesPerSec() float64 {
+	if x != nil {
+		return x.RxBytesPerSec
+	}
+	return 0
+}
+
+func (x *NetIfaceStats) GetTxBytesPerSec() float64 {
+	if x != nil {
+		return x.TxBytesPerSec
+	}
+	return 0
+}
+
+func (x *NetIfaceStats) GetSpeedMbps() float64 {
+	if x != nil {
+		return x.SpeedMbps
+	}
+	return 0
+}
+
+func (x *NetIfaceStats) GetDuplex() string {
+	if x != nil {
+		return x.Duplex
+	}
+	return ""
+}
+
+func (x *NetIfaceStats) GetMtu() float64 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+func (x *NetIfaceStats) GetOperstate() string {
+	if x != nil {
+		return x.Operstate
+	}
+	return ""
+}
+
+func (x *NetIfaceStats) GetCarrierChanges() float64 {
+	if x != nil {
+		return x.CarrierChanges
+	}
+	return 0
+}
+
+func (x *NetIfaceStats) GetCarrierChangesPeriod() float64 {
+	if x != nil {
+		return x.CarrierChangesPeriod
+	}
+	return 0
+}
+
+func (x *NetIfaceStats) GetUtilizationPercent() float64 {
+	if x != nil {
+		return x.UtilizationPercent
+	}
+	return 0
+}
+
+func (x *NetIfaceStats) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+// Состояние программного RAID-массива (md)
+type RAIDArray struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"` // Состояние из mdstat/array_state
+	Health        RAIDHealth             `protobuf:"varint,4,opt,name=health,proto3,enum=proto.RAIDHealth" json:"health,omitempty"`
+	RaidDisks     int32                  `protobuf:"varint,5,opt,name=raid_disks,json=raidDisks,proto3" json:"raid_disks,omitempty"`
+	ActiveDisks   int32                  `protobuf:"varint,6,opt,name=active_disks,json=activeDisks,proto3" json:"active_disks,omitempty"`
+	Degraded      int32                  `protobuf:"varint,7,opt,name=degraded,proto3" json:"degraded,omitempty"` // Отсутствующих дисков
+	Members       []*RAIDMember          `protobuf:"bytes,8,rep,name=members,proto3" json:"members,omitempty"`
+	SyncAction    string                 `protobuf:"bytes,9,opt,name=sync_action,json=syncAction,proto3" json:"sync_action,omitempty"`            // resync, recovery, check, reshape, idle
+	SyncProgress  float64                `protobuf:"fixed64,10,opt,name=sync_progress,json=syncProgress,proto3" json:"sync_progress,omitempty"`   // Прогресс операции, %
+	SyncSpeedKbs  float64                `protobuf:"fixed64,11,opt,name=sync_speed_kbs,json=syncSpeedKbs,proto3" json:"sync_speed_kbs,omitempty"` // Скорость операции, KB/s
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RAIDArray) Reset() {
+	*x = RAIDArray{}
+	mi := &file_proto_monitoring_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RAIDArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RAIDArray) ProtoMessage() {}
+
+func (x *RAIDArray) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RAIDArray.ProtoReflect.Descriptor instead.
+func (*RAIDArray) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RAIDArray) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RAIDArray) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *RAIDArray) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *RAIDArray) GetHealth() RAIDHealth {
+	if x != nil {
+		return x.Health
+	}
+	return RAIDHealth_RAID_HEALTH_UNKNOWN
+}
+
+func (x *RAIDArray) GetRaidDisks() int32 {
+	if x != nil {
+		return x.RaidDisks
+	}
+	return 0
+}
+
+func (x *RAIDArray) GetActiveDisks() int32 {
+	if x != nil {
+		return x.ActiveDisks
+	}
+	return 0
+}
+
+func (x *RAIDArray) GetDegraded() int32 {
+	if x != nil {
+		return x.Degraded
+	}
+	return 0
+}
+
+func (x *RAIDArray) GetMembers() []*RAIDMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *RAIDArray) GetSyncAction() string {
+	if x != nil {
+		return x.SyncAction
+	}
+	return ""
+}
+
+func (x *RAIDArray) GetSyncProgress() float64 {
+	if x != nil {
+		return x.SyncProgress
+	}
+	return 0
+}
+
+func (x *RAIDArray) GetSyncSpeedKbs() float64 {
+	if x != nil {
+		return x.SyncSpeedKbs
+	}
+	return 0
+}
+
+type RAIDMember struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Interval      int32                  `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"` // Интервал обновления (N)
-	Duration      int32                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"` // Период усреднения (M)
+	Device        string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Role          int32                  `protobuf:"varint,2,opt,name=role,proto3" json:"role,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"` // in_sync, faulty, spare, write_mostly
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StatsRequest) Reset() {
-	*x = StatsRequest{}
-	mi := &file_proto_monitoring_proto_msgTypes[0]
+func (x *RAIDMember) Reset() {
+	*x = RAIDMember{}
+	mi := &file_proto_monitoring_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatsRequest) String() string {
+func (x *RAIDMember) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatsRequest) ProtoMessage() {}
+func (*RAIDMember) ProtoMessage() {}
 
-func (x *StatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_monitoring_proto_msgTypes[0]
+func (x *RAIDMember) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -55,55 +3037,68 @@ func (x *StatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
-func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_monitoring_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use RAIDMember.ProtoReflect.Descriptor instead.
+func (*RAIDMember) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *StatsRequest) GetInterval() int32 {
+func (x *RAIDMember) GetDevice() string {
 	if x != nil {
-		return x.Interval
+		return x.Device
 	}
-	return 0
+	return ""
 }
 
-func (x *StatsRequest) GetDuration() int32 {
+func (x *RAIDMember) GetRole() int32 {
 	if x != nil {
-		return x.Duration
+		return x.Role
 	}
 	return 0
 }
 
-// Ответ со статистикой
-type StatsResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	LoadAverage_1Min  float64                `protobuf:"fixed64,1,opt,name=load_average_1min,json=loadAverage1min,proto3" json:"load_average_1min,omitempty"`
-	LoadAverage_5Min  float64                `protobuf:"fixed64,2,opt,name=load_average_5min,json=loadAverage5min,proto3" json:"load_average_5min,omitempty"`
-	LoadAverage_15Min float64                `protobuf:"fixed64,3,opt,name=load_average_15min,json=loadAverage15min,proto3" json:"load_average_15min,omitempty"`
-	CpuUser           float64                `protobuf:"fixed64,4,opt,name=cpu_user,json=cpuUser,proto3" json:"cpu_user,omitempty"`       // Процент времени CPU в user mode
-	CpuSystem         float64                `protobuf:"fixed64,5,opt,name=cpu_system,json=cpuSystem,proto3" json:"cpu_system,omitempty"` // Процент времени CPU в system mode
-	CpuIdle           float64                `protobuf:"fixed64,6,opt,name=cpu_idle,json=cpuIdle,proto3" json:"cpu_idle,omitempty"`       // Процент времени CPU в idle
-	DiskStats         []*DiskStats           `protobuf:"bytes,7,rep,name=disk_stats,json=diskStats,proto3" json:"disk_stats,omitempty"`
-	FilesystemStats   []*FilesystemStats     `protobuf:"bytes,8,rep,name=filesystem_stats,json=filesystemStats,proto3" json:"filesystem_stats,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *RAIDMember) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
 }
 
-func (x *StatsResponse) Reset() {
-	*x = StatsResponse{}
-	mi := &file_proto_monitoring_proto_msgTypes[1]
+// Блочное устройство из /sys/block
+type BlockDevice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dev           string                 `protobuf:"bytes,2,opt,name=dev,proto3" json:"dev,omitempty"`       // major:minor
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`     // disk, part, dm, md, loop
+	Parent        string                 `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"` // Диск раздела
+	SizeBytes     float64                `protobuf:"fixed64,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Rotational    bool                   `protobuf:"varint,6,opt,name=rotational,proto3" json:"rotational,omitempty"`
+	Model         string                 `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
+	Scheduler     string                 `protobuf:"bytes,8,opt,name=scheduler,proto3" json:"scheduler,omitempty"`         // Активный планировщик ввода-вывода
+	DmName        string                 `protobuf:"bytes,9,opt,name=dm_name,json=dmName,proto3" json:"dm_name,omitempty"` // Имя device-mapper (LVM)
+	Partitions    []string               `protobuf:"bytes,10,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	Holders       []string               `protobuf:"bytes,11,rep,name=holders,proto3" json:"holders,omitempty"`                      // Устройства поверх (dm, md)
+	Slaves        []string               `protobuf:"bytes,12,rep,name=slaves,proto3" json:"slaves,omitempty"`                        // Устройства под dm/md
+	MountedAt     []string               `protobuf:"bytes,13,rep,name=mounted_at,json=mountedAt,proto3" json:"mounted_at,omitempty"` // Точки монтирования самого устройства
+	Mountpoints   []string               `protobuf:"bytes,14,rep,name=mountpoints,proto3" json:"mountpoints,omitempty"`              // Все обслуживаемые точки монтирования
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockDevice) Reset() {
+	*x = BlockDevice{}
+	mi := &file_proto_monitoring_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatsResponse) String() string {
+func (x *BlockDevice) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatsResponse) ProtoMessage() {}
+func (*BlockDevice) ProtoMessage() {}
 
-func (x *StatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_monitoring_proto_msgTypes[1]
+func (x *BlockDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,93 +3109,141 @@ func (x *StatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
-func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_monitoring_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use BlockDevice.ProtoReflect.Descriptor instead.
+func (*BlockDevice) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *StatsResponse) GetLoadAverage_1Min() float64 {
+func (x *BlockDevice) GetName() string {
 	if x != nil {
-		return x.LoadAverage_1Min
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *StatsResponse) GetLoadAverage_5Min() float64 {
+func (x *BlockDevice) GetDev() string {
 	if x != nil {
-		return x.LoadAverage_5Min
+		return x.Dev
 	}
-	return 0
+	return ""
 }
 
-func (x *StatsResponse) GetLoadAverage_15Min() float64 {
+func (x *BlockDevice) GetType() string {
 	if x != nil {
-		return x.LoadAverage_15Min
+		return x.Type
 	}
-	return 0
+	return ""
 }
 
-func (x *StatsResponse) GetCpuUser() float64 {
+func (x *BlockDevice) GetParent() string {
 	if x != nil {
-		return x.CpuUser
+		return x.Parent
 	}
-	return 0
+	return ""
 }
 
-func (x *StatsResponse) GetCpuSystem() float64 {
+func (x *BlockDevice) GetSizeBytes() float64 {
 	if x != nil {
-		return x.CpuSystem
+		return x.SizeBytes
 	}
 	return 0
 }
 
-func (x *StatsResponse) GetCpuIdle() float64 {
+func (x *BlockDevice) GetRotational() bool {
 	if x != nil {
-		return x.CpuIdle
+		return x.Rotational
 	}
-	return 0
+	return false
 }
 
-func (x *StatsResponse) GetDiskStats() []*DiskStats {
+func (x *BlockDevice) GetModel() string {
 	if x != nil {
-		return x.DiskStats
+		return x.Model
+	}
+	return ""
+}
+
+func (x *BlockDevice) GetScheduler() string {
+	if x != nil {
+		return x.Scheduler
+	}
+	return ""
+}
+
+func (x *BlockDevice) GetDmName() string {
+	if x != nil {
+		return x.DmName
+	}
+	return ""
+}
+
+func (x *BlockDevice) GetPartitions() []string {
+	if x != nil {
+		return x.Partitions
 	}
 	return nil
 }
 
-func (x *StatsResponse) GetFilesystemStats() []*FilesystemStats {
+func (x *BlockDevice) GetHolders() []string {
 	if x != nil {
-		return x.FilesystemStats
+		return x.Holders
 	}
 	return nil
 }
 
-type DiskStats struct {
+func (x *BlockDevice) GetSlaves() []string {
+	if x != nil {
+		return x.Slaves
+	}
+	return nil
+}
+
+func (x *BlockDevice) GetMountedAt() []string {
+	if x != nil {
+		return x.MountedAt
+	}
+	return nil
+}
+
+func (x *BlockDevice) GetMountpoints() []string {
+	if x != nil {
+		return x.Mountpoints
+	}
+	return nil
+}
+
+// Внутренние метрики демона. Гистограммы и счётчики ошибок накапливаются с запуска демона
+type DaemonStats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Device        string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
-	Tps           float64                `protobuf:"fixed64,2,opt,name=tps,proto3" json:"tps,omitempty"`
-	KbRead        float64                `protobuf:"fixed64,3,opt,name=kb_read,json=kbRead,proto3" json:"kb_read,omitempty"`    // Для будущего разделения
-	KbWrite       float64                `protobuf:"fixed64,4,opt,name=kb_write,json=kbWrite,proto3" json:"kb_write,omitempty"` // Для будущего разделения
-	KbTotal       float64                `protobuf:"fixed64,5,opt,name=kb_total,json=kbTotal,proto3" json:"kb_total,omitempty"` // Сумма чтения и записи
+	CpuPercent    float64                `protobuf:"fixed64,1,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"` // Загрузка CPU процессом демона, % одного ядра
+	RssMb         float64                `protobuf:"fixed64,2,opt,name=rss_mb,json=rssMb,proto3" json:"rss_mb,omitempty"`                // Резидентная память процесса, МБ
+	Goroutines    float64                `protobuf:"fixed64,3,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	Streams       []*StreamCount         `protobuf:"bytes,4,rep,name=streams,proto3" json:"streams,omitempty"`
+	QueueDepth    float64                `protobuf:"fixed64,5,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`           // Сообщений во всех очередях отправки клиентам
+	MaxQueueDepth int32                  `protobuf:"varint,6,opt,name=max_queue_depth,json=maxQueueDepth,proto3" json:"max_queue_depth,omitempty"` // Самая длинная очередь отправки клиенту
+	// Длительность замеров подсистем только общим движком: потоки клиентов отвечают
+	// из его замеров и своих коллекторов не запускают
+	Collectors    []*LatencyHistogram `protobuf:"bytes,7,rep,name=collectors,proto3" json:"collectors,omitempty"`
+	Commands      []*LatencyHistogram `protobuf:"bytes,8,rep,name=commands,proto3" json:"commands,omitempty"` // Длительность внешних команд общего движка (df)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DiskStats) Reset() {
-	*x = DiskStats{}
-	mi := &file_proto_monitoring_proto_msgTypes[2]
+func (x *DaemonStats) Reset() {
+	*x = DaemonStats{}
+	mi := &file_proto_monitoring_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DiskStats) String() string {
+func (x *DaemonStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DiskStats) ProtoMessage() {}
+func (*DaemonStats) ProtoMessage() {}
 
-func (x *DiskStats) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_monitoring_proto_msgTypes[2]
+func (x *DaemonStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,73 +3254,91 @@ func (x *DiskStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DiskStats.ProtoReflect.Descriptor instead.
-func (*DiskStats) Descriptor() ([]byte, []int) {
-	return file_proto_monitoring_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use DaemonStats.ProtoReflect.Descriptor instead.
+func (*DaemonStats) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *DiskStats) GetDevice() string {
+func (x *DaemonStats) GetCpuPercent() float64 {
 	if x != nil {
-		return x.Device
+		return x.CpuPercent
 	}
-	return ""
+	return 0
 }
 
-func (x *DiskStats) GetTps() float64 {
+func (x *DaemonStats) GetRssMb() float64 {
 	if x != nil {
-		return x.Tps
+		return x.RssMb
 	}
 	return 0
 }
 
-func (x *DiskStats) GetKbRead() float64 {
+func (x *DaemonStats) GetGoroutines() float64 {
 	if x != nil {
-		return x.KbRead
+		return x.Goroutines
 	}
 	return 0
 }
 
-func (x *DiskStats) GetKbWrite() float64 {
+func (x *DaemonStats) GetStreams() []*StreamCount {
 	if x != nil {
-		return x.KbWrite
+		return x.Streams
+	}
+	return nil
+}
+
+func (x *DaemonStats) GetQueueDepth() float64 {
+	if x != nil {
+		return x.QueueDepth
 	}
 	return 0
 }
 
-func (x *DiskStats) GetKbTotal() float64 {
+func (x *DaemonStats) GetMaxQueueDepth() int32 {
 	if x != nil {
-		return x.KbTotal
+		return x.MaxQueueDepth
 	}
 	return 0
 }
 
-type FilesystemStats struct {
+func (x *DaemonStats) GetCollectors() []*LatencyHistogram {
+	if x != nil {
+		return x.Collectors
+	}
+	return nil
+}
+
+func (x *DaemonStats) GetCommands() []*LatencyHistogram {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+// Активные потоки одного метода
+type StreamCount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filesystem    string                 `protobuf:"bytes,1,opt,name=filesystem,proto3" json:"filesystem,omitempty"`
-	Mountpoint    string                 `protobuf:"bytes,2,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
-	UsedMb        float64                `protobuf:"fixed64,3,opt,name=used_mb,json=usedMb,proto3" json:"used_mb,omitempty"`
-	UsedPercent   float64                `protobuf:"fixed64,4,opt,name=used_percent,json=usedPercent,proto3" json:"used_percent,omitempty"`
-	InodesUsed    float64                `protobuf:"fixed64,5,opt,name=inodes_used,json=inodesUsed,proto3" json:"inodes_used,omitempty"`
-	InodesPercent float64                `protobuf:"fixed64,6,opt,name=inodes_percent,json=inodesPercent,proto3" json:"inodes_percent,omitempty"`
+	Rpc           string                 `protobuf:"bytes,1,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	Active        int32                  `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FilesystemStats) Reset() {
-	*x = FilesystemStats{}
-	mi := &file_proto_monitoring_proto_msgTypes[3]
+func (x *StreamCount) Reset() {
+	*x = StreamCount{}
+	mi := &file_proto_monitoring_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FilesystemStats) String() string {
+func (x *StreamCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FilesystemStats) ProtoMessage() {}
+func (*StreamCount) ProtoMessage() {}
 
-func (x *FilesystemStats) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_monitoring_proto_msgTypes[3]
+func (x *StreamCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,49 +3349,106 @@ func (x *FilesystemStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FilesystemStats.ProtoReflect.Descriptor instead.
-func (*FilesystemStats) Descriptor() ([]byte, []int) {
-	return file_proto_monitoring_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use StreamCount.ProtoReflect.Descriptor instead.
+func (*StreamCount) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *FilesystemStats) GetFilesystem() string {
+func (x *StreamCount) GetRpc() string {
 	if x != nil {
-		return x.Filesystem
+		return x.Rpc
 	}
 	return ""
 }
 
-func (x *FilesystemStats) GetMountpoint() string {
+func (x *StreamCount) GetActive() int32 {
 	if x != nil {
-		return x.Mountpoint
+		return x.Active
+	}
+	return 0
+}
+
+// Гистограмма длительностей замеров или команд
+type LatencyHistogram struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                  // Подсистема или команда
+	BoundsMs      []float64              `protobuf:"fixed64,2,rep,packed,name=bounds_ms,json=boundsMs,proto3" json:"bounds_ms,omitempty"` // Верхние границы корзин, мс
+	Counts        []uint64               `protobuf:"varint,3,rep,packed,name=counts,proto3" json:"counts,omitempty"`                      // Замеров в корзинах; последняя - длиннее всех границ
+	Count         uint64                 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	SumMs         float64                `protobuf:"fixed64,5,opt,name=sum_ms,json=sumMs,proto3" json:"sum_ms,omitempty"`
+	Errors        uint64                 `protobuf:"varint,6,opt,name=errors,proto3" json:"errors,omitempty"` // Неудачные замеры (входят в count)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyHistogram) Reset() {
+	*x = LatencyHistogram{}
+	mi := &file_proto_monitoring_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyHistogram) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyHistogram) ProtoMessage() {}
+
+func (x *LatencyHistogram) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitoring_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyHistogram.ProtoReflect.Descriptor instead.
+func (*LatencyHistogram) Descriptor() ([]byte, []int) {
+	return file_proto_monitoring_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *LatencyHistogram) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *FilesystemStats) GetUsedMb() float64 {
+func (x *LatencyHistogram) GetBoundsMs() []float64 {
 	if x != nil {
-		return x.UsedMb
+		return x.BoundsMs
 	}
-	return 0
+	return nil
 }
 
-func (x *FilesystemStats) GetUsedPercent() float64 {
+func (x *LatencyHistogram) GetCounts() []uint64 {
 	if x != nil {
-		return x.UsedPercent
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *LatencyHistogram) GetCount() uint64 {
+	if x != nil {
+		return x.Count
 	}
 	return 0
 }
 
-func (x *FilesystemStats) GetInodesUsed() float64 {
+func (x *LatencyHistogram) GetSumMs() float64 {
 	if x != nil {
-		return x.InodesUsed
+		return x.SumMs
 	}
 	return 0
 }
 
-func (x *FilesystemStats) GetInodesPercent() float64 {
+func (x *LatencyHistogram) GetErrors() uint64 {
 	if x != nil {
-		return x.InodesPercent
+		return x.Errors
 	}
 	return 0
 }
@@ -339,64 +3457,574 @@ var File_proto_monitoring_proto protoreflect.FileDescriptor
 
 var file_proto_monitoring_proto_rawDesc = string([]byte{
 	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69,
-	0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0x46, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x64,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xde, 0x02, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x6f, 0x61,
-	0x64, 0x5f, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x31, 0x6d, 0x69, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67,
-	0x65, 0x31, 0x6d, 0x69, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61, 0x76,
-	0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x35, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x0f, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x35, 0x6d, 0x69,
-	0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67,
-	0x65, 0x5f, 0x31, 0x35, 0x6d, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6c,
-	0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x31, 0x35, 0x6d, 0x69, 0x6e, 0x12,
-	0x19, 0x0a, 0x08, 0x63, 0x70, 0x75, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x07, 0x63, 0x70, 0x75, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x70,
-	0x75, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09,
-	0x63, 0x70, 0x75, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x70, 0x75,
-	0x5f, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x63, 0x70, 0x75,
-	0x49, 0x64, 0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x74, 0x61,
-	0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b,
-	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x41, 0x0a, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73,
-	0x74, 0x65, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74,
-	0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73,
-	0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x09, 0x44, 0x69, 0x73,
-	0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x74, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x74, 0x70, 0x73,
-	0x12, 0x17, 0x0a, 0x07, 0x6b, 0x62, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x06, 0x6b, 0x62, 0x52, 0x65, 0x61, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x62, 0x5f,
-	0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6b, 0x62, 0x57,
-	0x72, 0x69, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x62, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6b, 0x62, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22,
-	0xd5, 0x01, 0x0a, 0x0f, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65,
-	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73,
-	0x74, 0x65, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x62, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x75, 0x73, 0x65, 0x64, 0x4d, 0x62, 0x12, 0x21, 0x0a, 0x0c,
-	0x75, 0x73, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x14, 0x0a, 0x12, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x9f, 0x03, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12,
+	0x31, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x32, 0x0a, 0x09, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x61, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61, 0x6c, 0x66, 0x5f, 0x6c, 0x69,
+	0x66, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x68, 0x61, 0x6c, 0x66, 0x4c, 0x69,
+	0x66, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x67, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x67, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x10, 0x53, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x64, 0x69,
+	0x73, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x08, 0x66, 0x64, 0x5f, 0x74, 0x6f, 0x70, 0x5f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x64, 0x54, 0x6f, 0x70, 0x4e, 0x22,
+	0x90, 0x02, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12,
+	0x31, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x32, 0x0a, 0x09, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x61, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61, 0x6c, 0x66, 0x5f, 0x6c, 0x69,
+	0x66, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x68, 0x61, 0x6c, 0x66, 0x4c, 0x69,
+	0x66, 0x65, 0x22, 0x64, 0x0a, 0x12, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x30, 0x0a, 0x09, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x66,
+	0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb7, 0x01, 0x0a, 0x09, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x12, 0x2e, 0x0a, 0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x22, 0xe2, 0x01, 0x0a, 0x10, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65,
+	0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6f, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x52, 0x08, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x27,
+	0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4d, 0x65, 0x74,
+	0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x22, 0xba, 0x03, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x42, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x70, 0x61, 0x6e, 0x69, 0x63, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x61, 0x6e, 0x69, 0x63,
+	0x12, 0x42, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x70, 0x61, 0x6e, 0x69, 0x63, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x61, 0x6e, 0x69, 0x63,
+	0x54, 0x69, 0x6d, 0x65, 0x22, 0xca, 0x02, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x3d, 0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x65,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x45, 0x6e, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68,
+	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68,
+	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6f, 0x6f, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6f, 0x6f, 0x74, 0x49, 0x64,
+	0x12, 0x38, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x0a,
+	0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61,
+	0x6c, 0x66, 0x5f, 0x6c, 0x69, 0x66, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x68,
+	0x61, 0x6c, 0x66, 0x4c, 0x69, 0x66, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65,
+	0x64, 0x22, 0xcc, 0x01, 0x0a, 0x0e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x74, 0x65, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70,
+	0x22, 0x5f, 0x0a, 0x0f, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x88, 0x01, 0x0a, 0x0d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x2b, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x8e, 0x01, 0x0a,
+	0x0c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2e, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x8f, 0x06,
+	0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12,
+	0x27, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4d, 0x65,
+	0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0a,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x03,
+	0x63, 0x70, 0x75, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x43, 0x50, 0x55, 0x55, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x03, 0x63, 0x70,
+	0x75, 0x12, 0x2a, 0x0a, 0x04, 0x64, 0x69, 0x73, 0x6b, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x64, 0x69, 0x73, 0x6b, 0x12, 0x3c, 0x0a,
+	0x0a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x20, 0x0a, 0x02, 0x66,
+	0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x46, 0x44, 0x53, 0x74, 0x61, 0x74, 0x73, 0x48, 0x00, 0x52, 0x02, 0x66, 0x64, 0x12, 0x33, 0x0a,
+	0x09, 0x6e, 0x65, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x48, 0x00, 0x52, 0x08, 0x6e, 0x65, 0x74, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x37, 0x0a, 0x09, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x66, 0x61, 0x63, 0x65, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x65,
+	0x74, 0x49, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x08, 0x6e, 0x65, 0x74, 0x49, 0x66, 0x61, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x72,
+	0x61, 0x69, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x41, 0x49, 0x44, 0x41, 0x72, 0x72, 0x61, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x04, 0x72, 0x61, 0x69, 0x64, 0x12, 0x3d, 0x0a, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x48, 0x00, 0x52, 0x06, 0x64, 0x61,
+	0x65, 0x6d, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22,
+	0x66, 0x0a, 0x0b, 0x4c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x31, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x31, 0x6d, 0x69, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6c,
+	0x6f, 0x61, 0x64, 0x5f, 0x35, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x6c, 0x6f, 0x61, 0x64, 0x35, 0x6d, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x61, 0x64,
+	0x5f, 0x31, 0x35, 0x6d, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f,
+	0x61, 0x64, 0x31, 0x35, 0x6d, 0x69, 0x6e, 0x22, 0x4a, 0x0a, 0x08, 0x43, 0x50, 0x55, 0x55, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12,
+	0x12, 0x0a, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x69,
+	0x64, 0x6c, 0x65, 0x22, 0x37, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x73, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x22, 0x4f, 0x0a, 0x13,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x49, 0x0a,
+	0x11, 0x4e, 0x65, 0x74, 0x49, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x34, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e,
+	0x65, 0x74, 0x49, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x22, 0x39, 0x0a, 0x0d, 0x52, 0x41, 0x49, 0x44,
+	0x41, 0x72, 0x72, 0x61, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x61, 0x72, 0x72,
+	0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x41, 0x49, 0x44, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x06, 0x61, 0x72, 0x72,
+	0x61, 0x79, 0x73, 0x22, 0x3f, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x22, 0xdc, 0x06, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x31, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0f, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x31, 0x6d,
+	0x69, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61, 0x76, 0x65, 0x72, 0x61,
+	0x67, 0x65, 0x5f, 0x35, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6c,
+	0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x35, 0x6d, 0x69, 0x6e, 0x12, 0x2c,
+	0x0a, 0x12, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x31,
+	0x35, 0x6d, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6c, 0x6f, 0x61, 0x64,
+	0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x31, 0x35, 0x6d, 0x69, 0x6e, 0x12, 0x19, 0x0a, 0x08,
+	0x63, 0x70, 0x75, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
+	0x63, 0x70, 0x75, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x70, 0x75, 0x5f, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x63, 0x70, 0x75,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x70, 0x75, 0x5f, 0x69, 0x64,
+	0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x63, 0x70, 0x75, 0x49, 0x64, 0x6c,
+	0x65, 0x12, 0x2f, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69,
+	0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x41, 0x0a, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x0f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x08, 0x66, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x46, 0x44, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x66, 0x64, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x3c, 0x0a, 0x0f, 0x6e, 0x65, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4e, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x0d, 0x6e, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x3c,
+	0x0a, 0x0f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4e, 0x65, 0x74, 0x49, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0d, 0x6e,
+	0x65, 0x74, 0x49, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x0b,
+	0x72, 0x61, 0x69, 0x64, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x41, 0x49, 0x44, 0x41, 0x72,
+	0x72, 0x61, 0x79, 0x52, 0x0a, 0x72, 0x61, 0x69, 0x64, 0x41, 0x72, 0x72, 0x61, 0x79, 0x73, 0x12,
+	0x37, 0x0a, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0c, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74,
+	0x61, 0x12, 0x30, 0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x10, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x35, 0x0a, 0x0c,
+	0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0b, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x22, 0x6a, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x2b, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x2d, 0x0a, 0x06, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x22,
+	0x8a, 0x01, 0x0a, 0x0e, 0x57, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x12, 0x2f, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x61,
+	0x63, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x9b, 0x01, 0x0a,
+	0x0b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0xc0, 0x01, 0x0a, 0x09, 0x44,
+	0x69, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x74, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x74,
+	0x70, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x62, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x6b, 0x62, 0x52, 0x65, 0x61, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6b,
+	0x62, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6b,
+	0x62, 0x57, 0x72, 0x69, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x62, 0x5f, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6b, 0x62, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x98, 0x02,
+	0x0a, 0x0f, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x62, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x75, 0x73, 0x65, 0x64, 0x4d, 0x62, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x73,
+	0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x75, 0x73, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0a, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12, 0x25,
+	0x0a, 0x0e, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67,
+	0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e,
+	0x62, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x91, 0x01, 0x0a, 0x07, 0x46, 0x44, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x03, 0x6d, 0x61, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x64,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x46, 0x44, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0xb7, 0x01, 0x0a,
+	0x0e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x46, 0x44, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f,
+	0x70, 0x65, 0x6e, 0x5f, 0x66, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6f,
+	0x70, 0x65, 0x6e, 0x46, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x75, 0x73, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
 	0x28, 0x01, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12,
-	0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x55, 0x73, 0x65, 0x64,
-	0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65,
-	0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x69, 0x6e, 0x6f, 0x64, 0x65, 0x73,
-	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x32, 0x45, 0x0a, 0x0a, 0x4d, 0x6f, 0x6e, 0x69, 0x74,
-	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x37, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x36,
-	0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68, 0x61,
-	0x67, 0x72, 0x61, 0x74, 0x31, 0x36, 0x34, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2d, 0x6d,
-	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2d, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x25, 0x0a, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6f, 0x76, 0x65, 0x72, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x40, 0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x08,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x22, 0x68, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x22, 0x86, 0x03, 0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x49, 0x66, 0x61, 0x63, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x10, 0x72, 0x78, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0d, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65,
+	0x63, 0x12, 0x27, 0x0a, 0x10, 0x74, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70, 0x65,
+	0x72, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x74, 0x78, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x5f, 0x6d, 0x62, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x4d, 0x62, 0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x75, 0x70,
+	0x6c, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x75, 0x70, 0x6c, 0x65,
+	0x78, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03,
+	0x6d, 0x74, 0x75, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x63, 0x61, 0x72, 0x72,
+	0x69, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x63, 0x61,
+	0x72, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x5f, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x63, 0x61, 0x72, 0x72,
+	0x69, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x12, 0x2f, 0x0a, 0x13, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x75,
+	0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xed, 0x02, 0x0a, 0x09,
+	0x52, 0x41, 0x49, 0x44, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x68, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x41, 0x49, 0x44, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x06, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x61, 0x69, 0x64, 0x5f, 0x64, 0x69, 0x73,
+	0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x61, 0x69, 0x64, 0x44, 0x69,
+	0x73, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x69,
+	0x73, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x44, 0x69, 0x73, 0x6b, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x64, 0x12, 0x2b, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x41, 0x49, 0x44,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x79, 0x6e, 0x63, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x73, 0x79, 0x6e, 0x63, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x5f, 0x6b, 0x62, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x73,
+	0x79, 0x6e, 0x63, 0x53, 0x70, 0x65, 0x65, 0x64, 0x4b, 0x62, 0x73, 0x22, 0x4e, 0x0a, 0x0a, 0x52,
+	0x41, 0x49, 0x44, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0xfe, 0x02, 0x0a, 0x0b,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x64, 0x65, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x65,
+	0x76, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72,
+	0x12, 0x17, 0x0a, 0x07, 0x64, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x68, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x73, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x09, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0xca, 0x02, 0x0a,
+	0x0b, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x70, 0x75, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0a, 0x63, 0x70, 0x75, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a,
+	0x06, 0x72, 0x73, 0x73, 0x5f, 0x6d, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x72,
+	0x73, 0x73, 0x4d, 0x62, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x67, 0x6f, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x74,
+	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65,
+	0x70, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61,
+	0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x37, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52,
+	0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x22, 0x37, 0x0a, 0x0b, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x70, 0x63, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x70, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x22, 0xa0, 0x01, 0x0a, 0x10, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x73, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x08,
+	0x62, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x75, 0x6d, 0x5f, 0x6d, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x75, 0x6d, 0x4d, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x2a, 0x39, 0x0a, 0x0d, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x69,
+	0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x56, 0x45, 0x52, 0x41, 0x47,
+	0x49, 0x4e, 0x47, 0x5f, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e,
+	0x41, 0x56, 0x45, 0x52, 0x41, 0x47, 0x49, 0x4e, 0x47, 0x5f, 0x45, 0x57, 0x4d, 0x41, 0x10, 0x01,
+	0x2a, 0xba, 0x01, 0x0a, 0x0b, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x10, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x4d, 0x45, 0x41, 0x4e, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x41,
+	0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x41, 0x58, 0x10, 0x02,
+	0x12, 0x13, 0x0a, 0x0f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x50, 0x35, 0x30, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x39, 0x35, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x47,
+	0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x39, 0x39, 0x10, 0x05, 0x12,
+	0x16, 0x0a, 0x12, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53,
+	0x54, 0x44, 0x44, 0x45, 0x56, 0x10, 0x06, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x47, 0x47, 0x52, 0x45,
+	0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x41, 0x53, 0x54, 0x10, 0x07, 0x2a, 0x3b, 0x0a,
+	0x10, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4b, 0x69, 0x6e,
+	0x64, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x5f, 0x57, 0x41, 0x52, 0x4d,
+	0x55, 0x50, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x5f, 0x48,
+	0x45, 0x41, 0x52, 0x54, 0x42, 0x45, 0x41, 0x54, 0x10, 0x01, 0x2a, 0x37, 0x0a, 0x0f, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a,
+	0x0c, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x12, 0x0a, 0x0e, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45,
+	0x44, 0x10, 0x01, 0x2a, 0x86, 0x01, 0x0a, 0x0a, 0x52, 0x41, 0x49, 0x44, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x41, 0x49, 0x44, 0x5f, 0x48, 0x45, 0x41, 0x4c, 0x54,
+	0x48, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52,
+	0x41, 0x49, 0x44, 0x5f, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x5f, 0x4f, 0x4b, 0x10, 0x01, 0x12,
+	0x19, 0x0a, 0x15, 0x52, 0x41, 0x49, 0x44, 0x5f, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x5f, 0x52,
+	0x45, 0x53, 0x59, 0x4e, 0x43, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x52, 0x41,
+	0x49, 0x44, 0x5f, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x5f, 0x44, 0x45, 0x47, 0x52, 0x41, 0x44,
+	0x45, 0x44, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x41, 0x49, 0x44, 0x5f, 0x48, 0x45, 0x41,
+	0x4c, 0x54, 0x48, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x32, 0xb8, 0x02, 0x0a,
+	0x0a, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x37, 0x0a, 0x08, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
+	0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01,
+	0x12, 0x36, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3d, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x48, 0x0a, 0x05, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x12, 0x3f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x73, 0x68, 0x61, 0x67, 0x72, 0x61, 0x74, 0x31, 0x36, 0x34, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x2d, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2d, 0x64, 0x61, 0x65,
+	0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 })
 
 var (
@@ -411,23 +4039,136 @@ func file_proto_monitoring_proto_rawDescGZIP() []byte {
 	return file_proto_monitoring_proto_rawDescData
 }
 
-var file_proto_monitoring_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_monitoring_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_monitoring_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_proto_monitoring_proto_goTypes = []any{
-	(*StatsRequest)(nil),    // 0: proto.StatsRequest
-	(*StatsResponse)(nil),   // 1: proto.StatsResponse
-	(*DiskStats)(nil),       // 2: proto.DiskStats
-	(*FilesystemStats)(nil), // 3: proto.FilesystemStats
+	(AveragingMode)(0),            // 0: proto.AveragingMode
+	(Aggregation)(0),              // 1: proto.Aggregation
+	(StreamStatusKind)(0),         // 2: proto.StreamStatusKind
+	(EntityEventKind)(0),          // 3: proto.EntityEventKind
+	(RAIDHealth)(0),               // 4: proto.RAIDHealth
+	(*DaemonStatsRequest)(nil),    // 5: proto.DaemonStatsRequest
+	(*StatsRequest)(nil),          // 6: proto.StatsRequest
+	(*SubsystemOptions)(nil),      // 7: proto.SubsystemOptions
+	(*SnapshotRequest)(nil),       // 8: proto.SnapshotRequest
+	(*AggregationRequest)(nil),    // 9: proto.AggregationRequest
+	(*Aggregate)(nil),             // 10: proto.Aggregate
+	(*SnapshotResponse)(nil),      // 11: proto.SnapshotResponse
+	(*SubsystemCoverage)(nil),     // 12: proto.SubsystemCoverage
+	(*SnapshotMeta)(nil),          // 13: proto.SnapshotMeta
+	(*HistoryRequest)(nil),        // 14: proto.HistoryRequest
+	(*HistoryResponse)(nil),       // 15: proto.HistoryResponse
+	(*HistorySeries)(nil),         // 16: proto.HistorySeries
+	(*HistoryPoint)(nil),          // 17: proto.HistoryPoint
+	(*SubsystemUpdate)(nil),       // 18: proto.SubsystemUpdate
+	(*LoadAverage)(nil),           // 19: proto.LoadAverage
+	(*CPUUsage)(nil),              // 20: proto.CPUUsage
+	(*DiskStatsList)(nil),         // 21: proto.DiskStatsList
+	(*FilesystemStatsList)(nil),   // 22: proto.FilesystemStatsList
+	(*NetIfaceStatsList)(nil),     // 23: proto.NetIfaceStatsList
+	(*RAIDArrayList)(nil),         // 24: proto.RAIDArrayList
+	(*BlockDeviceList)(nil),       // 25: proto.BlockDeviceList
+	(*StatsResponse)(nil),         // 26: proto.StatsResponse
+	(*StreamStatus)(nil),          // 27: proto.StreamStatus
+	(*WarmupProgress)(nil),        // 28: proto.WarmupProgress
+	(*EntityEvent)(nil),           // 29: proto.EntityEvent
+	(*DiskStats)(nil),             // 30: proto.DiskStats
+	(*FilesystemStats)(nil),       // 31: proto.FilesystemStats
+	(*FDStats)(nil),               // 32: proto.FDStats
+	(*ProcessFDStats)(nil),        // 33: proto.ProcessFDStats
+	(*NetProtoStats)(nil),         // 34: proto.NetProtoStats
+	(*ProtoCounter)(nil),          // 35: proto.ProtoCounter
+	(*NetIfaceStats)(nil),         // 36: proto.NetIfaceStats
+	(*RAIDArray)(nil),             // 37: proto.RAIDArray
+	(*RAIDMember)(nil),            // 38: proto.RAIDMember
+	(*BlockDevice)(nil),           // 39: proto.BlockDevice
+	(*DaemonStats)(nil),           // 40: proto.DaemonStats
+	(*StreamCount)(nil),           // 41: proto.StreamCount
+	(*LatencyHistogram)(nil),      // 42: proto.LatencyHistogram
+	(*timestamppb.Timestamp)(nil), // 43: google.protobuf.Timestamp
 }
 var file_proto_monitoring_proto_depIdxs = []int32{
-	2, // 0: proto.StatsResponse.disk_stats:type_name -> proto.DiskStats
-	3, // 1: proto.StatsResponse.filesystem_stats:type_name -> proto.FilesystemStats
-	0, // 2: proto.Monitoring.GetStats:input_type -> proto.StatsRequest
-	1, // 3: proto.Monitoring.GetStats:output_type -> proto.StatsResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	7,  // 0: proto.StatsRequest.options:type_name -> proto.SubsystemOptions
+	9,  // 1: proto.StatsRequest.aggregations:type_name -> proto.AggregationRequest
+	0,  // 2: proto.StatsRequest.averaging:type_name -> proto.AveragingMode
+	7,  // 3: proto.SnapshotRequest.options:type_name -> proto.SubsystemOptions
+	9,  // 4: proto.SnapshotRequest.aggregations:type_name -> proto.AggregationRequest
+	0,  // 5: proto.SnapshotRequest.averaging:type_name -> proto.AveragingMode
+	1,  // 6: proto.AggregationRequest.functions:type_name -> proto.Aggregation
+	1,  // 7: proto.Aggregate.function:type_name -> proto.Aggregation
+	26, // 8: proto.SnapshotResponse.stats:type_name -> proto.StatsResponse
+	12, // 9: proto.SnapshotResponse.coverage:type_name -> proto.SubsystemCoverage
+	13, // 10: proto.SnapshotResponse.meta:type_name -> proto.SnapshotMeta
+	43, // 11: proto.SubsystemCoverage.last_error_time:type_name -> google.protobuf.Timestamp
+	43, // 12: proto.SubsystemCoverage.last_panic_time:type_name -> google.protobuf.Timestamp
+	43, // 13: proto.SnapshotMeta.window_start:type_name -> google.protobuf.Timestamp
+	43, // 14: proto.SnapshotMeta.window_end:type_name -> google.protobuf.Timestamp
+	12, // 15: proto.SnapshotMeta.subsystems:type_name -> proto.SubsystemCoverage
+	43, // 16: proto.HistoryRequest.from:type_name -> google.protobuf.Timestamp
+	43, // 17: proto.HistoryRequest.to:type_name -> google.protobuf.Timestamp
+	16, // 18: proto.HistoryResponse.series:type_name -> proto.HistorySeries
+	17, // 19: proto.HistorySeries.points:type_name -> proto.HistoryPoint
+	43, // 20: proto.HistoryPoint.time:type_name -> google.protobuf.Timestamp
+	43, // 21: proto.SubsystemUpdate.time:type_name -> google.protobuf.Timestamp
+	13, // 22: proto.SubsystemUpdate.meta:type_name -> proto.SnapshotMeta
+	10, // 23: proto.SubsystemUpdate.aggregates:type_name -> proto.Aggregate
+	29, // 24: proto.SubsystemUpdate.events:type_name -> proto.EntityEvent
+	27, // 25: proto.SubsystemUpdate.status:type_name -> proto.StreamStatus
+	19, // 26: proto.SubsystemUpdate.load_average:type_name -> proto.LoadAverage
+	20, // 27: proto.SubsystemUpdate.cpu:type_name -> proto.CPUUsage
+	21, // 28: proto.SubsystemUpdate.disk:type_name -> proto.DiskStatsList
+	22, // 29: proto.SubsystemUpdate.filesystem:type_name -> proto.FilesystemStatsList
+	32, // 30: proto.SubsystemUpdate.fd:type_name -> proto.FDStats
+	34, // 31: proto.SubsystemUpdate.net_proto:type_name -> proto.NetProtoStats
+	23, // 32: proto.SubsystemUpdate.net_iface:type_name -> proto.NetIfaceStatsList
+	24, // 33: proto.SubsystemUpdate.raid:type_name -> proto.RAIDArrayList
+	25, // 34: proto.SubsystemUpdate.block_devices:type_name -> proto.BlockDeviceList
+	40, // 35: proto.SubsystemUpdate.daemon:type_name -> proto.DaemonStats
+	30, // 36: proto.DiskStatsList.disks:type_name -> proto.DiskStats
+	31, // 37: proto.FilesystemStatsList.filesystems:type_name -> proto.FilesystemStats
+	36, // 38: proto.NetIfaceStatsList.interfaces:type_name -> proto.NetIfaceStats
+	37, // 39: proto.RAIDArrayList.arrays:type_name -> proto.RAIDArray
+	39, // 40: proto.BlockDeviceList.devices:type_name -> proto.BlockDevice
+	30, // 41: proto.StatsResponse.disk_stats:type_name -> proto.DiskStats
+	31, // 42: proto.StatsResponse.filesystem_stats:type_name -> proto.FilesystemStats
+	32, // 43: proto.StatsResponse.fd_stats:type_name -> proto.FDStats
+	34, // 44: proto.StatsResponse.net_proto_stats:type_name -> proto.NetProtoStats
+	36, // 45: proto.StatsResponse.net_iface_stats:type_name -> proto.NetIfaceStats
+	37, // 46: proto.StatsResponse.raid_arrays:type_name -> proto.RAIDArray
+	39, // 47: proto.StatsResponse.block_devices:type_name -> proto.BlockDevice
+	13, // 48: proto.StatsResponse.meta:type_name -> proto.SnapshotMeta
+	10, // 49: proto.StatsResponse.aggregates:type_name -> proto.Aggregate
+	29, // 50: proto.StatsResponse.events:type_name -> proto.EntityEvent
+	27, // 51: proto.StatsResponse.status:type_name -> proto.StreamStatus
+	40, // 52: proto.StatsResponse.daemon_stats:type_name -> proto.DaemonStats
+	2,  // 53: proto.StreamStatus.kind:type_name -> proto.StreamStatusKind
+	28, // 54: proto.StreamStatus.warmup:type_name -> proto.WarmupProgress
+	43, // 55: proto.EntityEvent.time:type_name -> google.protobuf.Timestamp
+	3,  // 56: proto.EntityEvent.kind:type_name -> proto.EntityEventKind
+	33, // 57: proto.FDStats.processes:type_name -> proto.ProcessFDStats
+	35, // 58: proto.NetProtoStats.counters:type_name -> proto.ProtoCounter
+	4,  // 59: proto.RAIDArray.health:type_name -> proto.RAIDHealth
+	38, // 60: proto.RAIDArray.members:type_name -> proto.RAIDMember
+	41, // 61: proto.DaemonStats.streams:type_name -> proto.StreamCount
+	42, // 62: proto.DaemonStats.collectors:type_name -> proto.LatencyHistogram
+	42, // 63: proto.DaemonStats.commands:type_name -> proto.LatencyHistogram
+	6,  // 64: proto.Monitoring.GetStats:input_type -> proto.StatsRequest
+	8,  // 65: proto.Monitoring.GetSnapshot:input_type -> proto.SnapshotRequest
+	6,  // 66: proto.Monitoring.Subscribe:input_type -> proto.StatsRequest
+	6,  // 67: proto.Monitoring.Watch:input_type -> proto.StatsRequest
+	14, // 68: proto.Monitoring.QueryHistory:input_type -> proto.HistoryRequest
+	5,  // 69: proto.Admin.GetDaemonStats:input_type -> proto.DaemonStatsRequest
+	26, // 70: proto.Monitoring.GetStats:output_type -> proto.StatsResponse
+	11, // 71: proto.Monitoring.GetSnapshot:output_type -> proto.SnapshotResponse
+	18, // 72: proto.Monitoring.Subscribe:output_type -> proto.SubsystemUpdate
+	26, // 73: proto.Monitoring.Watch:output_type -> proto.StatsResponse
+	15, // 74: proto.Monitoring.QueryHistory:output_type -> proto.HistoryResponse
+	40, // 75: proto.Admin.GetDaemonStats:output_type -> proto.DaemonStats
+	70, // [70:76] is the sub-list for method output_type
+	64, // [64:70] is the sub-list for method input_type
+	64, // [64:64] is the sub-list for extension type_name
+	64, // [64:64] is the sub-list for extension extendee
+	0,  // [0:64] is the sub-list for field type_name
 }
 
 func init() { file_proto_monitoring_proto_init() }
@@ -435,18 +4176,31 @@ func file_proto_monitoring_proto_init() {
 	if File_proto_monitoring_proto != nil {
 		return
 	}
+	file_proto_monitoring_proto_msgTypes[13].OneofWrappers = []any{
+		(*SubsystemUpdate_LoadAverage)(nil),
+		(*SubsystemUpdate_Cpu)(nil),
+		(*SubsystemUpdate_Disk)(nil),
+		(*SubsystemUpdate_Filesystem)(nil),
+		(*SubsystemUpdate_Fd)(nil),
+		(*SubsystemUpdate_NetProto)(nil),
+		(*SubsystemUpdate_NetIface)(nil),
+		(*SubsystemUpdate_Raid)(nil),
+		(*SubsystemUpdate_BlockDevices)(nil),
+		(*SubsystemUpdate_Daemon)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_monitoring_proto_rawDesc), len(file_proto_monitoring_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      5,
+			NumMessages:   38,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_proto_monitoring_proto_goTypes,
 		DependencyIndexes: file_proto_monitoring_proto_depIdxs,
+		EnumInfos:         file_proto_monitoring_proto_enumTypes,
 		MessageInfos:      file_proto_monitoring_proto_msgTypes,
 	}.Build()
 	File_proto_monitoring_proto = out.File
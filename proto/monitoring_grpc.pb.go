@@ -19,7 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Monitoring_GetStats_FullMethodName = "/proto.Monitoring/GetStats"
+	Monitoring_GetStats_FullMethodName     = "/proto.Monitoring/GetStats"
+	Monitoring_GetSnapshot_FullMethodName  = "/proto.Monitoring/GetSnapshot"
+	Monitoring_Subscribe_FullMethodName    = "/proto.Monitoring/Subscribe"
+	Monitoring_Watch_FullMethodName        = "/proto.Monitoring/Watch"
+	Monitoring_QueryHistory_FullMethodName = "/proto.Monitoring/QueryHistory"
 )
 
 // MonitoringClient is the client API for Monitoring service.
@@ -30,6 +34,15 @@ const (
 type MonitoringClient interface {
 	// Метод для получения статистики
 	GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatsResponse], error)
+	// Разовый запрос усреднённых значений по уже накопленным демоном замерам
+	GetSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*SnapshotResponse, error)
+	// Поток обновлений по подсистемам: каждая подсистема приходит отдельным сообщением в своём ритме
+	Subscribe(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubsystemUpdate], error)
+	// Двунаправленный поток: новые настройки (N, M, подсистемы) применяются без переподключения,
+	// ответ по уже накопленным замерам приходит сразу
+	Watch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StatsRequest, StatsResponse], error)
+	// История значений метрики за период (сырые точки или усреднённые по шагу)
+	QueryHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
 }
 
 type monitoringClient struct {
@@ -59,6 +72,58 @@ func (c *monitoringClient) GetStats(ctx context.Context, in *StatsRequest, opts
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Monitoring_GetStatsClient = grpc.ServerStreamingClient[StatsResponse]
 
+func (c *monitoringClient) GetSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*SnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnapshotResponse)
+	err := c.cc.Invoke(ctx, Monitoring_GetSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *monitoringClient) Subscribe(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubsystemUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Monitoring_ServiceDesc.Streams[1], Monitoring_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StatsRequest, SubsystemUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitoring_SubscribeClient = grpc.ServerStreamingClient[SubsystemUpdate]
+
+func (c *monitoringClient) Watch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StatsRequest, StatsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Monitoring_ServiceDesc.Streams[2], Monitoring_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StatsRequest, StatsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitoring_WatchClient = grpc.BidiStreamingClient[StatsRequest, StatsResponse]
+
+func (c *monitoringClient) QueryHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, Monitoring_QueryHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MonitoringServer is the server API for Monitoring service.
 // All implementations must embed UnimplementedMonitoringServer
 // for forward compatibility.
@@ -67,6 +132,15 @@ type Monitoring_GetStatsClient = grpc.ServerStreamingClient[StatsResponse]
 type MonitoringServer interface {
 	// Метод для получения статистики
 	GetStats(*StatsRequest, grpc.ServerStreamingServer[StatsResponse]) error
+	// Разовый запрос усреднённых значений по уже накопленным демоном замерам
+	GetSnapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error)
+	// Поток обновлений по подсистемам: каждая подсистема приходит отдельным сообщением в своём ритме
+	Subscribe(*StatsRequest, grpc.ServerStreamingServer[SubsystemUpdate]) error
+	// Двунаправленный поток: новые настройки (N, M, подсистемы) применяются без переподключения,
+	// ответ по уже накопленным замерам приходит сразу
+	Watch(grpc.BidiStreamingServer[StatsRequest, StatsResponse]) error
+	// История значений метрики за период (сырые точки или усреднённые по шагу)
+	QueryHistory(context.Context, *HistoryRequest) (*HistoryResponse, error)
 	mustEmbedUnimplementedMonitoringServer()
 }
 
@@ -80,6 +154,18 @@ type UnimplementedMonitoringServer struct{}
 func (UnimplementedMonitoringServer) GetStats(*StatsRequest, grpc.ServerStreamingServer[StatsResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
+func (UnimplementedMonitoringServer) GetSnapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
+}
+func (UnimplementedMonitoringServer) Subscribe(*StatsRequest, grpc.ServerStreamingServer[SubsystemUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedMonitoringServer) Watch(grpc.BidiStreamingServer[StatsRequest, StatsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedMonitoringServer) QueryHistory(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryHistory not implemented")
+}
 func (UnimplementedMonitoringServer) mustEmbedUnimplementedMonitoringServer() {}
 func (UnimplementedMonitoringServer) testEmbeddedByValue()                    {}
 
@@ -112,19 +198,201 @@ func _Monitoring_GetStats_Handler(srv interface{}, stream grpc.ServerStream) err
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Monitoring_GetStatsServer = grpc.ServerStreamingServer[StatsResponse]
 
+func _Monitoring_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MonitoringServer).GetSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Monitoring_GetSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MonitoringServer).GetSnapshot(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Monitoring_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MonitoringServer).Subscribe(m, &grpc.GenericServerStream[StatsRequest, SubsystemUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitoring_SubscribeServer = grpc.ServerStreamingServer[SubsystemUpdate]
+
+func _Monitoring_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MonitoringServer).Watch(&grpc.GenericServerStream[StatsRequest, StatsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Monitoring_WatchServer = grpc.BidiStreamingServer[StatsRequest, StatsResponse]
+
+func _Monitoring_QueryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MonitoringServer).QueryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Monitoring_QueryHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MonitoringServer).QueryHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Monitoring_ServiceDesc is the grpc.ServiceDesc for Monitoring service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Monitoring_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Monitoring",
 	HandlerType: (*MonitoringServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSnapshot",
+			Handler:    _Monitoring_GetSnapshot_Handler,
+		},
+		{
+			MethodName: "QueryHistory",
+			Handler:    _Monitoring_QueryHistory_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "GetStats",
 			Handler:       _Monitoring_GetStats_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Monitoring_Subscribe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Monitoring_Watch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/monitoring.proto",
+}
+
+const (
+	Admin_GetDaemonStats_FullMethodName = "/proto.Admin/GetDaemonStats"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Служебный интерфейс демона
+type AdminClient interface {
+	// Внутренние метрики демона: загрузка CPU считается с предыдущего запроса
+	GetDaemonStats(ctx context.Context, in *DaemonStatsRequest, opts ...grpc.CallOption) (*DaemonStats, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) GetDaemonStats(ctx context.Context, in *DaemonStatsRequest, opts ...grpc.CallOption) (*DaemonStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DaemonStats)
+	err := c.cc.Invoke(ctx, Admin_GetDaemonStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility.
+//
+// Служебный интерфейс демона
+type AdminServer interface {
+	// Внутренние метрики демона: загрузка CPU считается с предыдущего запроса
+	GetDaemonStats(context.Context, *DaemonStatsRequest) (*DaemonStats, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServer struct{}
+
+func (UnimplementedAdminServer) GetDaemonStats(context.Context, *DaemonStatsRequest) (*DaemonStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDaemonStats not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+func (UnimplementedAdminServer) testEmbeddedByValue()               {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_GetDaemonStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DaemonStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetDaemonStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetDaemonStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetDaemonStats(ctx, req.(*DaemonStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDaemonStats",
+			Handler:    _Admin_GetDaemonStats_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/monitoring.proto",
 }